@@ -0,0 +1,91 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Workspace asset names
+const (
+	AssetLogo           = "logo"
+	AssetLogoDark       = "logo_dark"
+	AssetBackground     = "background"
+	AssetBackgroundDark = "background_dark"
+	AssetFaviconICO     = "favicon_ico"
+	AssetFavicon        = "favicon"
+	AssetFavicon16      = "favicon_16"
+	AssetFavicon32      = "favicon_32"
+	AssetFavicon192     = "favicon_192"
+	AssetFavicon512     = "favicon_512"
+	AssetTouchIcon180   = "touchicon_180"
+)
+
+type SetAssetRequest struct {
+	// AssetName is one of the Asset constants
+	AssetName string
+	Asset     io.Reader
+	FileName  string
+	// RefreshAllClients reloads the asset in every connected client
+	RefreshAllClients bool
+}
+
+type UnsetAssetRequest struct {
+	AssetName         string `json:"assetName"`
+	RefreshAllClients bool   `json:"refreshAllClients,omitempty"`
+}
+
+// SetAsset uploads a workspace asset such as the logo or the favicon.
+func (c *Client) SetAsset(param *SetAssetRequest) (*SimpleSuccessResponse, error) {
+	if param.AssetName == "" || param.Asset == nil || param.FileName == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := newMultipartRequest(
+		fmt.Sprintf("%s/%s/assets.setAsset", c.baseURL, c.apiVersion),
+		map[string]string{
+			"assetName":         param.AssetName,
+			"refreshAllClients": strconv.FormatBool(param.RefreshAllClients),
+		},
+		param.AssetName, param.FileName, param.Asset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UnsetAsset restores the default of a workspace asset.
+func (c *Client) UnsetAsset(param *UnsetAssetRequest) (*SimpleSuccessResponse, error) {
+	if param.AssetName == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/assets.unsetAsset", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,59 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/assets.setAsset", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "logo", r.FormValue("assetName"))
+		require.Equal(t, "true", r.FormValue("refreshAllClients"))
+
+		file, header, err := r.FormFile("logo")
+		require.NoError(t, err)
+		defer file.Close()
+		require.Equal(t, "logo.svg", header.Filename)
+		b, _ := ioutil.ReadAll(file)
+		require.Equal(t, "<svg/>", string(b))
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SetAsset(&SetAssetRequest{
+		AssetName:         AssetLogo,
+		Asset:             strings.NewReader("<svg/>"),
+		FileName:          "logo.svg",
+		RefreshAllClients: true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestUnsetAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/assets.unsetAsset", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"assetName": "favicon"}, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UnsetAsset(&UnsetAssetRequest{AssetName: AssetFavicon})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
@@ -2,24 +2,95 @@ package gorocket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 )
 
+const (
+	TwoFactorMethodTOTP     = "totp"
+	TwoFactorMethodEmail    = "email"
+	TwoFactorMethodPassword = "password"
+)
+
 type LoginPayload struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
 	Resume   string `json:"resume,omitempty"`
+	// Code is the TOTP or email two-factor code
+	Code string `json:"code,omitempty"`
+}
+
+type ResumeLoginPayload struct {
+	Resume string `json:"resume"`
+}
+
+type OAuthLoginPayload struct {
+	ServiceName string `json:"serviceName"`
+	AccessToken string `json:"accessToken"`
+	Secret      string `json:"secret,omitempty"`
+	ExpiresIn   int    `json:"expiresIn"`
+	// IdentityToken is used by services like Apple instead of AccessToken
+	IdentityToken string `json:"identityToken,omitempty"`
+}
+
+type LDAPLoginPayload struct {
+	Username    string                 `json:"username"`
+	LDAPPass    string                 `json:"ldapPass"`
+	LDAP        bool                   `json:"ldap"`
+	LDAPOptions map[string]interface{} `json:"ldapOptions"`
+}
+
+type CASLoginPayload struct {
+	CAS CASCredential `json:"cas"`
+}
+
+type CASCredential struct {
+	CredentialToken string `json:"credentialToken"`
+}
+
+type SAMLLoginPayload struct {
+	SAML            bool   `json:"saml"`
+	CredentialToken string `json:"credentialToken"`
 }
 
 type LoginResponse struct {
-	Status  string    `json:"status"`
-	Data    DataLogin `json:"data"`
-	Message string    `json:"message,omitempty"`
+	Status  string            `json:"status"`
+	Data    DataLogin         `json:"data"`
+	Message string            `json:"message,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Details *TwoFactorDetails `json:"details,omitempty"`
+}
+
+type TwoFactorDetails struct {
+	Method          string `json:"method"`
+	CodeGenerated   bool   `json:"codeGenerated,omitempty"`
+	EmailOrUsername string `json:"emailOrUsername,omitempty"`
+}
+
+// TwoFactorRequiredError is returned when the server asks for a two-factor
+// code, or rejects the one that was sent.
+type TwoFactorRequiredError struct {
+	Method  string
+	Invalid bool
+	Message string
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	if e.Invalid {
+		return fmt.Sprintf("invalid two-factor code for method %s", e.Method)
+	}
+
+	return fmt.Sprintf("two-factor code required for method %s", e.Method)
 }
 
+// TwoFactorCodeFunc returns the two-factor code for the requested method,
+// e.g. from a TOTP generator or from the email sent by the server.
+type TwoFactorCodeFunc func(method string) (string, error)
+
 type DataLogin struct {
 	UserID    string `json:"userId"`
 	AuthToken string `json:"authToken"`
@@ -136,7 +207,104 @@ type MeResponse struct {
 }
 
 // Login login the user with the given credentials.
+// A *TwoFactorRequiredError is returned along with the response if the user
+// has two-factor authentication enabled and login.Code is missing or invalid.
+// The login is not sent if the credential provider of the client cannot take
+// the new credentials, see WithCredentialProvider.
 func (c *Client) Login(login *LoginPayload) (*LoginResponse, error) {
+	return c.loginAs(login)
+}
+
+// LoginWithTwoFactor login the user and asks code for the two-factor code
+// if the server requires one.
+func (c *Client) LoginWithTwoFactor(login *LoginPayload, code TwoFactorCodeFunc) (*LoginResponse, error) {
+	if !c.canSetAuthToken() {
+		return nil, errLoginNotStored
+	}
+
+	res, err := c.login(context.Background(), login, code)
+
+	return c.storeLogin(res, err)
+}
+
+// LoginWithResumeToken login the user with a resume token of an existing session.
+func (c *Client) LoginWithResumeToken(token string) (*LoginResponse, error) {
+	return c.loginAs(&ResumeLoginPayload{Resume: token})
+}
+
+// LoginWithOAuth login the user with an access token of an OAuth service.
+func (c *Client) LoginWithOAuth(login *OAuthLoginPayload) (*LoginResponse, error) {
+	return c.loginAs(login)
+}
+
+// LoginWithLDAP login the user against the LDAP server.
+func (c *Client) LoginWithLDAP(login *LDAPLoginPayload) (*LoginResponse, error) {
+	payload := *login
+	payload.LDAP = true
+	if payload.LDAPOptions == nil {
+		payload.LDAPOptions = map[string]interface{}{}
+	}
+
+	return c.loginAs(&payload)
+}
+
+// LoginWithCAS login the user with the credential token of a CAS login.
+func (c *Client) LoginWithCAS(credentialToken string) (*LoginResponse, error) {
+	return c.loginAs(&CASLoginPayload{CAS: CASCredential{CredentialToken: credentialToken}})
+}
+
+// LoginWithSAML login the user with the credential token of a SAML login.
+func (c *Client) LoginWithSAML(credentialToken string) (*LoginResponse, error) {
+	return c.loginAs(&SAMLLoginPayload{SAML: true, CredentialToken: credentialToken})
+}
+
+// errLoginNotStored is returned by the logins of a client whose credential
+// provider cannot take the new credentials.
+var errLoginNotStored = errors.New("login not sent: the credential provider cannot take new credentials")
+
+// loginAs sends any kind of login payload and stores the credentials on success.
+func (c *Client) loginAs(login interface{}) (*LoginResponse, error) {
+	if !c.canSetAuthToken() {
+		return nil, errLoginNotStored
+	}
+
+	res, err := c.sendLogin(context.Background(), login)
+
+	return c.storeLogin(res, err)
+}
+
+func (c *Client) storeLogin(res *LoginResponse, err error) (*LoginResponse, error) {
+	if err != nil {
+		return res, err
+	}
+
+	// success auth
+	if res.Status == "success" {
+		c.setCredentials(res.Data.UserID, res.Data.AuthToken)
+	}
+
+	return res, nil
+}
+
+// login sends the login request without changing the client credentials.
+// If code is not nil, it is used to answer a two-factor challenge once.
+func (c *Client) login(ctx context.Context, login *LoginPayload, code TwoFactorCodeFunc) (*LoginResponse, error) {
+	res, err := c.sendLogin(ctx, login)
+
+	var tfErr *TwoFactorRequiredError
+	if code == nil || !errors.As(err, &tfErr) || tfErr.Invalid {
+		return res, err
+	}
+
+	payload := *login
+	if payload.Code, err = code(tfErr.Method); err != nil {
+		return res, err
+	}
+
+	return c.sendLogin(ctx, &payload)
+}
+
+func (c *Client) sendLogin(ctx context.Context, login interface{}) (*LoginResponse, error) {
 	opt, _ := json.Marshal(login)
 	url := fmt.Sprintf("%s/%s/login", c.baseURL, c.apiVersion)
 
@@ -149,14 +317,20 @@ func (c *Client) Login(login *LoginPayload) (*LoginResponse, error) {
 	}
 
 	res := LoginResponse{}
-	if err := c.sendRequest(req, &res); err != nil {
+	if err := c.sendAnonymous(req.WithContext(ctx), &res); err != nil {
 		return nil, err
 	}
 
-	// success auth
-	if res.Status == "success" {
-		c.userID = res.Data.UserID
-		c.xToken = res.Data.AuthToken
+	if res.Error == "totp-required" || res.Error == "totp-invalid" {
+		tfErr := &TwoFactorRequiredError{
+			Invalid: res.Error == "totp-invalid",
+			Message: res.Message,
+		}
+		if res.Details != nil {
+			tfErr.Method = res.Details.Method
+		}
+
+		return &res, tfErr
 	}
 
 	return &res, nil
@@ -1,6 +1,8 @@
 package gorocket
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -39,6 +41,130 @@ func TestLogin(t *testing.T) {
 	require.Equal(t, "http://localhost:3000/avatar/test", resp.Data.Me.AvatarURL)
 }
 
+func TestLoginTwoFactorRequired(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		Code:         http.StatusUnauthorized,
+		ResponseBody: `{"status":"error","error":"totp-required","message":"TOTP Required [totp-required]","details":{"method":"email","codeGenerated":true,"emailOrUsername":"rocket.cat"}}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.Login(&LoginPayload{User: "rocket.cat", Password: "password"})
+
+	tfErr, ok := err.(*TwoFactorRequiredError)
+	require.True(t, ok)
+	require.Equal(t, TwoFactorMethodEmail, tfErr.Method)
+	require.False(t, tfErr.Invalid)
+	require.Equal(t, "error", resp.Status)
+	require.True(t, resp.Details.CodeGenerated)
+}
+
+func TestLoginWithTwoFactor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := LoginPayload{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		if payload.Code != "123456" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write([]byte(`{"status":"error","error":"totp-required","message":"TOTP Required [totp-required]","details":{"method":"totp"}}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"status":"success","data":{"authToken":"9HqLlyZOugoStsXCUfD_0YdwnNnunAJF8V47U3QHXSq","userId":"aobEdbYhXfu5hkeqG"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithTwoFactor(&LoginPayload{User: "rocket.cat", Password: "password"}, func(method string) (string, error) {
+		require.Equal(t, TwoFactorMethodTOTP, method)
+		return "123456", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "success", resp.Status)
+
+	userID, xToken := client.credentials()
+	require.Equal(t, "aobEdbYhXfu5hkeqG", userID)
+	require.Equal(t, "9HqLlyZOugoStsXCUfD_0YdwnNnunAJF8V47U3QHXSq", xToken)
+}
+
+// loginHandler checks the login request body and replies with a successful login.
+func loginHandler(t *testing.T, expectedBody string) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		got, err := json.Marshal(body)
+		require.NoError(t, err)
+		require.JSONEq(t, expectedBody, string(got))
+
+		_, err = w.Write([]byte(`{"status":"success","data":{"authToken":"9HqLlyZOugoStsXCUfD_0YdwnNnunAJF8V47U3QHXSq","userId":"aobEdbYhXfu5hkeqG"}}`))
+		require.NoError(t, err)
+	})
+}
+
+func requireLoggedIn(t *testing.T, client *Client, resp *LoginResponse) {
+	require.Equal(t, "success", resp.Status)
+
+	userID, xToken := client.credentials()
+	require.Equal(t, "aobEdbYhXfu5hkeqG", userID)
+	require.Equal(t, "9HqLlyZOugoStsXCUfD_0YdwnNnunAJF8V47U3QHXSq", xToken)
+}
+
+func TestLoginWithResumeToken(t *testing.T) {
+	server := httptest.NewServer(loginHandler(t, `{"resume":"resume-token"}`))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithResumeToken("resume-token")
+	require.NoError(t, err)
+	requireLoggedIn(t, client, resp)
+}
+
+func TestLoginWithOAuth(t *testing.T) {
+	server := httptest.NewServer(loginHandler(t, `{"serviceName":"google","accessToken":"oauth-token","expiresIn":3600}`))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithOAuth(&OAuthLoginPayload{
+		ServiceName: "google",
+		AccessToken: "oauth-token",
+		ExpiresIn:   3600,
+	})
+	require.NoError(t, err)
+	requireLoggedIn(t, client, resp)
+}
+
+func TestLoginWithLDAP(t *testing.T) {
+	server := httptest.NewServer(loginHandler(t, `{"username":"john","ldapPass":"secret","ldap":true,"ldapOptions":{}}`))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithLDAP(&LDAPLoginPayload{Username: "john", LDAPPass: "secret"})
+	require.NoError(t, err)
+	requireLoggedIn(t, client, resp)
+}
+
+func TestLoginWithCAS(t *testing.T) {
+	server := httptest.NewServer(loginHandler(t, `{"cas":{"credentialToken":"cas-token"}}`))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithCAS("cas-token")
+	require.NoError(t, err)
+	requireLoggedIn(t, client, resp)
+}
+
+func TestLoginWithSAML(t *testing.T) {
+	server := httptest.NewServer(loginHandler(t, `{"saml":true,"credentialToken":"saml-token"}`))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LoginWithSAML("saml-token")
+	require.NoError(t, err)
+	requireLoggedIn(t, client, resp)
+}
+
 func TestMe(t *testing.T) {
 	server := httptest.NewServer(getHandler(t, &HandlerHelper{
 		ResponseBody: `{"_id":"aobEdbYhXfu5hkeqG","name":"Example User","emails":[{"address":"example@example.com","verified":true}],"status":"offline","statusConnection":"offline","username":"example","utcOffset":0,"active":true,"roles":["user","admin"],"settings":{"preferences":{"enableAutoAway":false,"idleTimeoutLimit":300,"desktopNotificationDuration":0,"audioNotifications":"mentions","desktopNotifications":"mentions","mobileNotifications":"mentions","unreadAlert":true,"useEmojis":true,"convertAsciiEmoji":true,"autoImageLoad":true,"saveMobileBandwidth":true,"collapseMediaByDefault":false,"hideUsernames":false,"hideRoles":false,"hideFlexTab":false,"hideAvatars":false,"roomsListExhibitionMode":"category","sidebarViewMode":"medium","sidebarHideAvatar":false,"sidebarShowUnread":false,"sidebarShowFavorites":true,"sendOnEnter":"normal","messageViewMode":0,"emailNotificationMode":"all","roomCounterSidebar":false,"newRoomNotification":"door","newMessageNotification":"chime","muteFocusedConversations":true,"notificationsSoundVolume":100}},"customFields":{"twitter":"@userstwi"},"avatarUrl":"http://localhost:3000/avatar/test","success":true}`,
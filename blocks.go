@@ -0,0 +1,433 @@
+package gorocket
+
+import (
+	"encoding/json"
+)
+
+const (
+	BlockTypeSection = "section"
+	BlockTypeActions = "actions"
+	BlockTypeContext = "context"
+	BlockTypeDivider = "divider"
+	BlockTypeImage   = "image"
+
+	ElementTypeButton       = "button"
+	ElementTypeStaticSelect = "static_select"
+	ElementTypeImage        = "image"
+	ElementTypePlainText    = "plain_text"
+	ElementTypeMarkdown     = "mrkdwn"
+
+	ButtonStylePrimary = "primary"
+	ButtonStyleDanger  = "danger"
+)
+
+// Block is a UI Kit layout block: *SectionBlock, *ActionsBlock,
+// *ContextBlock, *DividerBlock, *ImageBlock or *UnknownBlock.
+type Block interface {
+	BlockType() string
+}
+
+// Element is a UI Kit block element: *TextObject, *ButtonElement,
+// *StaticSelectElement, *ImageElement or *UnknownElement.
+type Element interface {
+	ElementType() string
+}
+
+// Blocks is a list of blocks which can be decoded from JSON.
+type Blocks []Block
+
+// Elements is a list of block elements which can be decoded from JSON.
+type Elements []Element
+
+type TextObject struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+type SectionBlock struct {
+	BlockID   string       `json:"blockId,omitempty"`
+	Text      *TextObject  `json:"text,omitempty"`
+	Fields    []TextObject `json:"fields,omitempty"`
+	Accessory Element      `json:"accessory,omitempty"`
+}
+
+type ActionsBlock struct {
+	BlockID  string   `json:"blockId,omitempty"`
+	Elements Elements `json:"elements"`
+}
+
+type ContextBlock struct {
+	BlockID  string   `json:"blockId,omitempty"`
+	Elements Elements `json:"elements"`
+}
+
+type DividerBlock struct {
+	BlockID string `json:"blockId,omitempty"`
+}
+
+type ImageBlock struct {
+	BlockID  string      `json:"blockId,omitempty"`
+	ImageURL string      `json:"imageUrl"`
+	AltText  string      `json:"altText"`
+	Title    *TextObject `json:"title,omitempty"`
+}
+
+// UnknownBlock keeps a block of a type this package does not model, so it
+// is encoded back unchanged.
+type UnknownBlock struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+type ButtonElement struct {
+	ActionID string     `json:"actionId"`
+	Text     TextObject `json:"text"`
+	Value    string     `json:"value,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+type StaticSelectElement struct {
+	ActionID      string         `json:"actionId"`
+	Placeholder   TextObject     `json:"placeholder"`
+	Options       []SelectOption `json:"options"`
+	InitialOption *SelectOption  `json:"initialOption,omitempty"`
+}
+
+type SelectOption struct {
+	Text  TextObject `json:"text"`
+	Value string     `json:"value"`
+}
+
+type ImageElement struct {
+	ImageURL string `json:"imageUrl"`
+	AltText  string `json:"altText"`
+}
+
+// UnknownElement keeps a block element of a type this package does not
+// model, so it is encoded back unchanged.
+type UnknownElement struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (SectionBlock) BlockType() string   { return BlockTypeSection }
+func (ActionsBlock) BlockType() string   { return BlockTypeActions }
+func (ContextBlock) BlockType() string   { return BlockTypeContext }
+func (DividerBlock) BlockType() string   { return BlockTypeDivider }
+func (ImageBlock) BlockType() string     { return BlockTypeImage }
+func (u UnknownBlock) BlockType() string { return u.Type }
+
+func (t TextObject) ElementType() string        { return t.Type }
+func (ButtonElement) ElementType() string       { return ElementTypeButton }
+func (StaticSelectElement) ElementType() string { return ElementTypeStaticSelect }
+func (ImageElement) ElementType() string        { return ElementTypeImage }
+func (u UnknownElement) ElementType() string    { return u.Type }
+
+// NewPlainText creates a plain text object.
+func NewPlainText(text string) *TextObject {
+	return &TextObject{Type: ElementTypePlainText, Text: text, Emoji: true}
+}
+
+// NewMarkdownText creates a markdown text object.
+func NewMarkdownText(text string) *TextObject {
+	return &TextObject{Type: ElementTypeMarkdown, Text: text}
+}
+
+// NewButton creates a button sending value with actionID when clicked.
+func NewButton(actionID, text, value string) *ButtonElement {
+	return &ButtonElement{
+		ActionID: actionID,
+		Text:     *NewPlainText(text),
+		Value:    value,
+	}
+}
+
+// Primary makes the button green.
+func (b *ButtonElement) Primary() *ButtonElement {
+	b.Style = ButtonStylePrimary
+	return b
+}
+
+// Danger makes the button red.
+func (b *ButtonElement) Danger() *ButtonElement {
+	b.Style = ButtonStyleDanger
+	return b
+}
+
+// NewStaticSelect creates a select sending the value of the chosen option with actionID.
+func NewStaticSelect(actionID, placeholder string, options ...SelectOption) *StaticSelectElement {
+	return &StaticSelectElement{
+		ActionID:    actionID,
+		Placeholder: *NewPlainText(placeholder),
+		Options:     options,
+	}
+}
+
+// NewSelectOption creates an option of a static select.
+func NewSelectOption(text, value string) SelectOption {
+	return SelectOption{Text: *NewPlainText(text), Value: value}
+}
+
+// BlocksBuilder builds the blocks of a message.
+type BlocksBuilder struct {
+	blocks Blocks
+}
+
+// NewBlocks creates a new blocks builder.
+func NewBlocks() *BlocksBuilder {
+	return &BlocksBuilder{}
+}
+
+// Section adds a section with text and an optional accessory element.
+func (b *BlocksBuilder) Section(text *TextObject, accessory ...Element) *BlocksBuilder {
+	section := &SectionBlock{Text: text}
+	if len(accessory) > 0 {
+		section.Accessory = accessory[0]
+	}
+
+	return b.Add(section)
+}
+
+// Fields adds a section with fields shown in two columns.
+func (b *BlocksBuilder) Fields(fields ...*TextObject) *BlocksBuilder {
+	section := &SectionBlock{}
+	for _, field := range fields {
+		section.Fields = append(section.Fields, *field)
+	}
+
+	return b.Add(section)
+}
+
+// Actions adds a block of interactive elements.
+func (b *BlocksBuilder) Actions(elements ...Element) *BlocksBuilder {
+	return b.Add(&ActionsBlock{Elements: elements})
+}
+
+// Context adds a block of small text and images.
+func (b *BlocksBuilder) Context(elements ...Element) *BlocksBuilder {
+	return b.Add(&ContextBlock{Elements: elements})
+}
+
+// Divider adds a divider.
+func (b *BlocksBuilder) Divider() *BlocksBuilder {
+	return b.Add(&DividerBlock{})
+}
+
+// Image adds an image.
+func (b *BlocksBuilder) Image(imageURL, altText string) *BlocksBuilder {
+	return b.Add(&ImageBlock{ImageURL: imageURL, AltText: altText})
+}
+
+// Add adds any block.
+func (b *BlocksBuilder) Add(block Block) *BlocksBuilder {
+	b.blocks = append(b.blocks, block)
+	return b
+}
+
+// Build returns the blocks.
+func (b *BlocksBuilder) Build() Blocks {
+	return append(Blocks(nil), b.blocks...)
+}
+
+func (s SectionBlock) MarshalJSON() ([]byte, error) {
+	type section SectionBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		section
+	}{BlockTypeSection, section(s)})
+}
+
+func (a ActionsBlock) MarshalJSON() ([]byte, error) {
+	type actions ActionsBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		actions
+	}{BlockTypeActions, actions(a)})
+}
+
+func (c ContextBlock) MarshalJSON() ([]byte, error) {
+	type context ContextBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		context
+	}{BlockTypeContext, context(c)})
+}
+
+func (d DividerBlock) MarshalJSON() ([]byte, error) {
+	type divider DividerBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		divider
+	}{BlockTypeDivider, divider(d)})
+}
+
+func (i ImageBlock) MarshalJSON() ([]byte, error) {
+	type image ImageBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		image
+	}{BlockTypeImage, image(i)})
+}
+
+func (b ButtonElement) MarshalJSON() ([]byte, error) {
+	type button ButtonElement
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		button
+	}{ElementTypeButton, button(b)})
+}
+
+func (s StaticSelectElement) MarshalJSON() ([]byte, error) {
+	type staticSelect StaticSelectElement
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		staticSelect
+	}{ElementTypeStaticSelect, staticSelect(s)})
+}
+
+func (i ImageElement) MarshalJSON() ([]byte, error) {
+	type image ImageElement
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		image
+	}{ElementTypeImage, image(i)})
+}
+
+func (u UnknownBlock) MarshalJSON() ([]byte, error) {
+	return marshalRaw(u.Raw)
+}
+
+func (u UnknownElement) MarshalJSON() ([]byte, error) {
+	return marshalRaw(u.Raw)
+}
+
+func marshalRaw(raw json.RawMessage) ([]byte, error) {
+	if len(raw) == 0 {
+		return []byte("null"), nil
+	}
+
+	return raw, nil
+}
+
+func (s *SectionBlock) UnmarshalJSON(data []byte) error {
+	type section SectionBlock
+	v := struct {
+		section
+		Accessory json.RawMessage `json:"accessory,omitempty"`
+	}{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = SectionBlock(v.section)
+	if len(v.Accessory) == 0 || string(v.Accessory) == "null" {
+		s.Accessory = nil
+		return nil
+	}
+
+	accessory, err := unmarshalElement(v.Accessory)
+	if err != nil {
+		return err
+	}
+	s.Accessory = accessory
+
+	return nil
+}
+
+func (b *Blocks) UnmarshalJSON(data []byte) error {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	blocks := make(Blocks, 0, len(raw))
+	for _, r := range raw {
+		block, err := unmarshalBlock(r)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, block)
+	}
+	*b = blocks
+
+	return nil
+}
+
+func (e *Elements) UnmarshalJSON(data []byte) error {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	elements := make(Elements, 0, len(raw))
+	for _, r := range raw {
+		element, err := unmarshalElement(r)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+	*e = elements
+
+	return nil
+}
+
+func unmarshalBlock(data json.RawMessage) (Block, error) {
+	var block Block
+
+	switch t := typeOf(data); t {
+	case BlockTypeSection:
+		block = &SectionBlock{}
+	case BlockTypeActions:
+		block = &ActionsBlock{}
+	case BlockTypeContext:
+		block = &ContextBlock{}
+	case BlockTypeDivider:
+		block = &DividerBlock{}
+	case BlockTypeImage:
+		block = &ImageBlock{}
+	default:
+		return &UnknownBlock{Type: t, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func unmarshalElement(data json.RawMessage) (Element, error) {
+	var element Element
+
+	switch t := typeOf(data); t {
+	case ElementTypePlainText, ElementTypeMarkdown:
+		element = &TextObject{}
+	case ElementTypeButton:
+		element = &ButtonElement{}
+	case ElementTypeStaticSelect:
+		element = &StaticSelectElement{}
+	case ElementTypeImage:
+		element = &ImageElement{}
+	default:
+		return &UnknownElement{Type: t, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+
+	if err := json.Unmarshal(data, element); err != nil {
+		return nil, err
+	}
+
+	return element, nil
+}
+
+// typeOf returns the type field of a block or element.
+func typeOf(data json.RawMessage) string {
+	v := struct {
+		Type string `json:"type"`
+	}{}
+	_ = json.Unmarshal(data, &v)
+
+	return v.Type
+}
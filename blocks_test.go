@@ -0,0 +1,99 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksMarshal(t *testing.T) {
+	blocks := NewBlocks().
+		Section(NewMarkdownText("*Deploy api to prod?*"), NewStaticSelect("env", "Environment",
+			NewSelectOption("Production", "prod"),
+			NewSelectOption("Staging", "staging"))).
+		Divider().
+		Actions(NewButton("approve", "Approve", "42").Primary(), NewButton("reject", "Reject", "42").Danger()).
+		Context(NewPlainText("requested by john")).
+		Image("https://example.com/graph.png", "graph").
+		Build()
+
+	b, err := json.Marshal(blocks)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"type":"section","text":{"type":"mrkdwn","text":"*Deploy api to prod?*"},"accessory":{"type":"static_select","actionId":"env","placeholder":{"type":"plain_text","text":"Environment","emoji":true},"options":[{"text":{"type":"plain_text","text":"Production","emoji":true},"value":"prod"},{"text":{"type":"plain_text","text":"Staging","emoji":true},"value":"staging"}]}},
+		{"type":"divider"},
+		{"type":"actions","elements":[{"type":"button","actionId":"approve","text":{"type":"plain_text","text":"Approve","emoji":true},"value":"42","style":"primary"},{"type":"button","actionId":"reject","text":{"type":"plain_text","text":"Reject","emoji":true},"value":"42","style":"danger"}]},
+		{"type":"context","elements":[{"type":"plain_text","text":"requested by john","emoji":true}]},
+		{"type":"image","imageUrl":"https://example.com/graph.png","altText":"graph"}
+	]`, string(b))
+}
+
+func TestBlocksUnmarshal(t *testing.T) {
+	blocks := NewBlocks().
+		Section(NewMarkdownText("Deploy?"), NewButton("approve", "Approve", "42")).
+		Fields(NewMarkdownText("*Env*"), NewPlainText("prod")).
+		Divider().
+		Actions(NewButton("reject", "Reject", "42").Danger()).
+		Context(&ImageElement{ImageURL: "https://example.com/a.png", AltText: "a"}).
+		Image("https://example.com/graph.png", "graph").
+		Build()
+
+	b, err := json.Marshal(blocks)
+	require.NoError(t, err)
+
+	decoded := Blocks{}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Equal(t, blocks, decoded)
+}
+
+func TestBlocksUnmarshalUnknown(t *testing.T) {
+	data := `[{"type":"video_conf","blockId":"b1","callId":"c1","appId":"videoconf-core"},{"type":"actions","elements":[{"type":"overflow","actionId":"more","options":[]},{"type":"button","actionId":"ok","text":{"type":"plain_text","text":"OK"}}]}]`
+
+	decoded := Blocks{}
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	require.Len(t, decoded, 2)
+	require.Equal(t, "video_conf", decoded[0].BlockType())
+
+	actions := decoded[1].(*ActionsBlock)
+	require.Equal(t, "overflow", actions.Elements[0].ElementType())
+	require.IsType(t, &UnknownElement{}, actions.Elements[0])
+	require.IsType(t, &ButtonElement{}, actions.Elements[1])
+
+	b, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, data, string(b))
+
+	res := GetMessageResponse{}
+	require.NoError(t, json.Unmarshal([]byte(`{"message":{"_id":"m1","msg":"","blocks":[{"type":"video_conf","callId":"c1"}]},"success":true}`), &res))
+	require.True(t, res.Success)
+}
+
+func TestPostMessageWithBlocks(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &body))
+		w.Write([]byte(`{"ts":1481748965123,"channel":"general","message":{"msg":"Deploy?","rid":"GENERAL","_id":"jC9chsFddTvsbFQG7","blocks":[{"type":"actions","elements":[{"type":"button","actionId":"approve","text":{"type":"plain_text","text":"Approve"},"value":"42"}]}]},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	msg := NewMessage().
+		Room("GENERAL").
+		Text("Deploy?").
+		Blocks(NewBlocks().Actions(NewButton("approve", "Approve", "42")).Build()...).
+		Build()
+
+	resp, err := client.PostMessage(msg)
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, "actions", body["blocks"].([]interface{})[0].(map[string]interface{})["type"])
+
+	button := resp.Message.Blocks[0].(*ActionsBlock).Elements[0].(*ButtonElement)
+	require.Equal(t, "approve", button.ActionID)
+	require.Equal(t, "42", button.Value)
+}
@@ -0,0 +1,190 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	CannedResponseScopeGlobal     = "global"
+	CannedResponseScopeDepartment = "department"
+	CannedResponseScopeUser       = "user"
+)
+
+type CannedResponse struct {
+	ID           string    `json:"_id"`
+	Shortcut     string    `json:"shortcut"`
+	Text         string    `json:"text"`
+	Scope        string    `json:"scope"`
+	Tags         []string  `json:"tags,omitempty"`
+	DepartmentID string    `json:"departmentId,omitempty"`
+	UserID       string    `json:"userId,omitempty"`
+	CreatedBy    UChat     `json:"createdBy"`
+	CreatedAt    time.Time `json:"_createdAt"`
+	UpdatedAt    time.Time `json:"_updatedAt"`
+}
+
+// CannedResponsesRequest filters the canned responses, all fields are optional.
+type CannedResponsesRequest struct {
+	Shortcut     string
+	Text         string
+	Scope        string
+	CreatedBy    string
+	DepartmentID string
+}
+
+type CannedResponsesResponse struct {
+	CannedResponses []CannedResponse `json:"cannedResponses"`
+	Offset          int              `json:"offset"`
+	Count           int              `json:"count"`
+	Total           int              `json:"total"`
+	Success         bool             `json:"success"`
+	Error           string           `json:"error,omitempty"`
+}
+
+type CannedResponseResponse struct {
+	CannedResponse CannedResponse `json:"cannedResponse"`
+	Success        bool           `json:"success"`
+	Error          string         `json:"error,omitempty"`
+}
+
+type CannedResponseRequest struct {
+	ID       string `json:"_id,omitempty"`
+	Shortcut string `json:"shortcut"`
+	Text     string `json:"text"`
+	// Scope is one of the CannedResponseScope constants
+	Scope string   `json:"scope"`
+	Tags  []string `json:"tags,omitempty"`
+	// DepartmentID is required for the department scope
+	DepartmentID string `json:"departmentId,omitempty"`
+}
+
+// CannedResponsesList lists the canned responses visible to the user.
+func (c *Client) CannedResponsesList(param *CannedResponsesRequest) (*CannedResponsesResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/canned-responses", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	if param.Shortcut != "" {
+		url.Add("shortcut", param.Shortcut)
+	}
+	if param.Text != "" {
+		url.Add("text", param.Text)
+	}
+	if param.Scope != "" {
+		url.Add("scope", param.Scope)
+	}
+	if param.CreatedBy != "" {
+		url.Add("createdBy", param.CreatedBy)
+	}
+	if param.DepartmentID != "" {
+		url.Add("departmentId", param.DepartmentID)
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := CannedResponsesResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CannedResponsesGet gets a canned response by id.
+func (c *Client) CannedResponsesGet(id string) (*CannedResponseResponse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/canned-responses/%s", c.baseURL, c.apiVersion, url.PathEscape(id)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := CannedResponseResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CannedResponsesCreate creates a canned response.
+func (c *Client) CannedResponsesCreate(param *CannedResponseRequest) (*SimpleSuccessResponse, error) {
+	if param.ID != "" || param.Shortcut == "" || param.Text == "" || param.Scope == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	return c.saveCannedResponse(param)
+}
+
+// CannedResponsesUpdate updates the canned response with the given id.
+func (c *Client) CannedResponsesUpdate(id string, param *CannedResponseRequest) (*SimpleSuccessResponse, error) {
+	if id == "" || param.Shortcut == "" || param.Text == "" || param.Scope == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	update := *param
+	update.ID = id
+
+	return c.saveCannedResponse(&update)
+}
+
+func (c *Client) saveCannedResponse(param *CannedResponseRequest) (*SimpleSuccessResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/canned-responses", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CannedResponsesDelete deletes a canned response.
+func (c *Client) CannedResponsesDelete(id string) (*SimpleSuccessResponse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{"_id": id})
+
+	req, err := http.NewRequest("DELETE",
+		fmt.Sprintf("%s/%s/canned-responses", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
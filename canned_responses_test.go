@@ -0,0 +1,101 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCannedResponsesList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/canned-responses", r.URL.Path)
+		require.Equal(t, "department", r.URL.Query().Get("scope"))
+		require.Equal(t, "sales", r.URL.Query().Get("departmentId"))
+
+		w.Write([]byte(`{"cannedResponses":[{"_id":"cr1","shortcut":"refund","text":"Refunds take 5 days","scope":"department","tags":["billing"],"departmentId":"sales","createdBy":{"_id":"u1","username":"john"},"_createdAt":"2021-01-01T10:00:00.000Z","_updatedAt":"2021-01-01T10:00:00.000Z"}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CannedResponsesList(&CannedResponsesRequest{Scope: CannedResponseScopeDepartment, DepartmentID: "sales"})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "refund", resp.CannedResponses[0].Shortcut)
+	require.Equal(t, "john", resp.CannedResponses[0].CreatedBy.Username)
+}
+
+func TestCannedResponsesGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/canned-responses/cr1", r.URL.Path)
+
+		w.Write([]byte(`{"cannedResponse":{"_id":"cr1","shortcut":"refund","text":"Refunds take 5 days","scope":"global"},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CannedResponsesGet("cr1")
+	require.NoError(t, err)
+	require.Equal(t, "Refunds take 5 days", resp.CannedResponse.Text)
+}
+
+func TestCannedResponsesCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"shortcut": "hi", "text": "Hello!", "scope": "global"}, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CannedResponsesCreate(&CannedResponseRequest{Shortcut: "hi", Text: "Hello!", Scope: CannedResponseScopeGlobal})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+
+	_, err = client.CannedResponsesCreate(&CannedResponseRequest{Shortcut: "hi"})
+	require.Error(t, err)
+}
+
+func TestCannedResponsesUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+
+		body := CannedResponseRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "cr1", body.ID)
+		require.Equal(t, "Hello again!", body.Text)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CannedResponsesUpdate("cr1", &CannedResponseRequest{Shortcut: "hi", Text: "Hello again!", Scope: CannedResponseScopeUser})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestCannedResponsesDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "DELETE", r.Method)
+		require.Equal(t, "/api/v1/canned-responses", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "cr1", body["_id"])
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CannedResponsesDelete("cr1")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
@@ -19,13 +19,14 @@ type AddAllResponse struct {
 }
 
 type Channel struct {
-	ID        string    `json:"_id"`
-	Name      string    `json:"name"`
-	T         string    `json:"t"`
-	Usernames []string  `json:"usernames"`
-	Msgs      int       `json:"msgs"`
-	U         U         `json:"u"`
-	Ts        time.Time `json:"ts"`
+	ID           string       `json:"_id"`
+	Name         string       `json:"name"`
+	T            string       `json:"t"`
+	Usernames    []string     `json:"usernames"`
+	Msgs         int          `json:"msgs"`
+	U            U            `json:"u"`
+	Ts           time.Time    `json:"ts"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
 }
 
 type SimpleChannelId struct {
@@ -50,9 +51,10 @@ type ChannelCountersResponse struct {
 }
 
 type CreateChannelRequest struct {
-	Name     string   `json:"name"`
-	Members  []string `json:"members,omitempty"`
-	ReadOnly bool     `json:"readOnly,omitempty"`
+	Name         string       `json:"name"`
+	Members      []string     `json:"members,omitempty"`
+	ReadOnly     bool         `json:"readOnly,omitempty"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
 }
 
 type CreateChannelResponse struct {
@@ -81,22 +83,21 @@ type ChannelInfoResponse struct {
 }
 
 type ChannelInfo struct {
-	ID           string `json:"_id"`
-	Name         string `json:"name"`
-	Fname        string `json:"fname"`
-	T            string `json:"t"`
-	Msgs         int    `json:"msgs"`
-	UsersCount   int    `json:"usersCount"`
-	U            UChat  `json:"u"`
-	CustomFields struct {
-	} `json:"customFields"`
-	Broadcast bool      `json:"broadcast"`
-	Encrypted bool      `json:"encrypted"`
-	Ts        time.Time `json:"ts"`
-	Ro        bool      `json:"ro"`
-	Default   bool      `json:"default"`
-	SysMes    bool      `json:"sysMes"`
-	UpdatedAt time.Time `json:"_updatedAt"`
+	ID           string       `json:"_id"`
+	Name         string       `json:"name"`
+	Fname        string       `json:"fname"`
+	T            string       `json:"t"`
+	Msgs         int          `json:"msgs"`
+	UsersCount   int          `json:"usersCount"`
+	U            UChat        `json:"u"`
+	CustomFields CustomFields `json:"customFields"`
+	Broadcast    bool         `json:"broadcast"`
+	Encrypted    bool         `json:"encrypted"`
+	Ts           time.Time    `json:"ts"`
+	Ro           bool         `json:"ro"`
+	Default      bool         `json:"default"`
+	SysMes       bool         `json:"sysMes"`
+	UpdatedAt    time.Time    `json:"_updatedAt"`
 }
 
 type InviteChannelRequest struct {
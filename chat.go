@@ -17,6 +17,7 @@ type Message struct {
 	RoomID      string       `json:"roomId,omitempty"`
 	Text        string       `json:"text"`
 	Attachments []Attachment `json:"attachments"`
+	Blocks      Blocks       `json:"blocks,omitempty"`
 }
 
 type Attachment struct {
@@ -36,6 +37,113 @@ type Attachment struct {
 	TitleLinkDownload bool          `json:"title_link_download,omitempty"`
 	Ts                time.Time     `json:"ts,omitempty"`
 	VideoURL          string        `json:"video_url,omitempty"`
+	// ButtonAlignment is ButtonAlignmentVertical or ButtonAlignmentHorizontal
+	ButtonAlignment string             `json:"button_alignment,omitempty"`
+	Actions         []AttachmentAction `json:"actions,omitempty"`
+}
+
+const (
+	AttachmentActionTypeButton = "button"
+
+	ButtonAlignmentVertical   = "vertical"
+	ButtonAlignmentHorizontal = "horizontal"
+)
+
+// AttachmentAction is a legacy interactive button of an attachment. It opens
+// URL, or posts Msg as the clicking user if MsgInChatWindow is set.
+type AttachmentAction struct {
+	Type      string `json:"type"`
+	Text      string `json:"text,omitempty"`
+	URL       string `json:"url,omitempty"`
+	ImageURL  string `json:"image_url,omitempty"`
+	IsWebview bool   `json:"is_webview,omitempty"`
+	// WebviewHeightRatio is full, tall or compact
+	WebviewHeightRatio string `json:"webview_height_ratio,omitempty"`
+	Msg                string `json:"msg,omitempty"`
+	// MsgInChatWindow posts Msg to the room as the user who clicked
+	MsgInChatWindow bool `json:"msg_in_chat_window,omitempty"`
+	// MsgProcessingType is sendMessage, respondWithMessage (puts Msg in the
+	// message box) or respondWithQuotedMessage
+	MsgProcessingType string `json:"msg_processing_type,omitempty"`
+}
+
+// NewURLAction creates a button opening url.
+func NewURLAction(text, url string) AttachmentAction {
+	return AttachmentAction{Type: AttachmentActionTypeButton, Text: text, URL: url}
+}
+
+// NewMessageAction creates a button sending msg to the room.
+func NewMessageAction(text, msg string) AttachmentAction {
+	return AttachmentAction{Type: AttachmentActionTypeButton, Text: text, Msg: msg, MsgInChatWindow: true}
+}
+
+// Validate checks the required fields of the action.
+func (a AttachmentAction) Validate() error {
+	if a.Type != AttachmentActionTypeButton {
+		return fmt.Errorf("action type must be %q, got %q", AttachmentActionTypeButton, a.Type)
+	}
+	if a.Text == "" && a.ImageURL == "" {
+		return fmt.Errorf("action needs a text or an image_url")
+	}
+	if a.URL == "" && a.Msg == "" {
+		return fmt.Errorf("action needs an url or a msg")
+	}
+	if a.IsWebview && a.URL == "" {
+		return fmt.Errorf("is_webview needs an url")
+	}
+	if a.MsgInChatWindow && a.Msg == "" {
+		return fmt.Errorf("msg_in_chat_window needs a msg")
+	}
+	if a.Msg != "" && a.URL == "" && !a.MsgInChatWindow {
+		return fmt.Errorf("msg needs msg_in_chat_window to be sent")
+	}
+
+	return nil
+}
+
+// Validate checks the actions of the attachment.
+func (a Attachment) Validate() error {
+	switch a.ButtonAlignment {
+	case "", ButtonAlignmentVertical, ButtonAlignmentHorizontal:
+	default:
+		return fmt.Errorf("unknown button_alignment %q", a.ButtonAlignment)
+	}
+
+	for i, action := range a.Actions {
+		if err := action.Validate(); err != nil {
+			return fmt.Errorf("action %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the attachments of the message.
+func (m *Message) Validate() error {
+	for i, attachment := range m.Attachments {
+		if err := attachment.Validate(); err != nil {
+			return fmt.Errorf("attachment %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON omits the timestamp if it is not set.
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type attachment Attachment
+
+	v := struct {
+		attachment
+		Ts *time.Time `json:"ts,omitempty"`
+	}{
+		attachment: attachment(a),
+	}
+	if !a.Ts.IsZero() {
+		v.Ts = &a.Ts
+	}
+
+	return json.Marshal(v)
 }
 
 type AttachField struct {
@@ -63,11 +171,44 @@ type RespMessageData struct {
 	Rid       string    `json:"rid,omitempty"`
 	UpdatedAt time.Time `json:"_updatedAt,omitempty"`
 	ID        string    `json:"_id,omitempty"`
+	Blocks    Blocks    `json:"blocks,omitempty"`
 }
 
 type UChat struct {
 	ID       string `json:"_id,omitempty"`
 	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// RoomMessage is a message as stored in a room.
+type RoomMessage struct {
+	ID          string              `json:"_id"`
+	Rid         string              `json:"rid"`
+	Msg         string              `json:"msg"`
+	T           string              `json:"t,omitempty"`
+	Ts          time.Time           `json:"ts"`
+	U           UChat               `json:"u"`
+	Alias       string              `json:"alias,omitempty"`
+	Attachments []Attachment        `json:"attachments,omitempty"`
+	Blocks      Blocks              `json:"blocks,omitempty"`
+	Mentions    []UChat             `json:"mentions,omitempty"`
+	Channels    []MessageChannel    `json:"channels,omitempty"`
+	Reactions   map[string]Reaction `json:"reactions,omitempty"`
+	Pinned      bool                `json:"pinned,omitempty"`
+	Tmid        string              `json:"tmid,omitempty"`
+	Tcount      int                 `json:"tcount,omitempty"`
+	EditedAt    *time.Time          `json:"editedAt,omitempty"`
+	EditedBy    *UChat              `json:"editedBy,omitempty"`
+	UpdatedAt   time.Time           `json:"_updatedAt"`
+}
+
+type MessageChannel struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+}
+
+type Reaction struct {
+	Usernames []string `json:"usernames"`
 }
 
 type SingleMessageId struct {
@@ -88,6 +229,32 @@ type MessageResp struct {
 		ID       string `json:"_id"`
 		Username string `json:"username"`
 	} `json:"u"`
+	Blocks Blocks `json:"blocks,omitempty"`
+}
+
+type UpdateMessageRequest struct {
+	RoomID string `json:"roomId"`
+	MsgID  string `json:"msgId"`
+	Text   string `json:"text"`
+	Blocks Blocks `json:"blocks,omitempty"`
+}
+
+type UpdateMessageResponse struct {
+	Message RespMessageData `json:"message"`
+	Success bool            `json:"success"`
+}
+
+type ChatSearchRequest struct {
+	RoomID     string
+	SearchText string
+	Count      int
+	Offset     int
+}
+
+type ChatSearchResponse struct {
+	Messages []RoomMessage `json:"messages"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
 }
 
 type DeleteMessageRequest struct {
@@ -161,8 +328,27 @@ type PinMessageResponse struct {
 	Success bool `json:"success"`
 }
 
+// ReadReceipt records when a user read a message.
+type ReadReceipt struct {
+	ID        string    `json:"_id"`
+	RoomID    string    `json:"roomId"`
+	UserID    string    `json:"userId"`
+	MessageID string    `json:"messageId"`
+	Ts        time.Time `json:"ts"`
+	User      UChat     `json:"user"`
+}
+
+type MessageReadReceiptsResponse struct {
+	Receipts []ReadReceipt `json:"receipts"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
+}
+
 // PostMessage posts a new chat message.
 func (c *Client) PostMessage(msg *Message) (*RespPostMessage, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
 
 	opt, _ := json.Marshal(msg)
 
@@ -213,6 +399,65 @@ func (c *Client) GetMessage(param *SingleMessageId) (*GetMessageResponse, error)
 	return &res, nil
 }
 
+// UpdateMessage updates the text and blocks of an existing chat message.
+func (c *Client) UpdateMessage(param *UpdateMessageRequest) (*UpdateMessageResponse, error) {
+	if param.RoomID == "" || param.MsgID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/chat.update", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := UpdateMessageResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ChatSearch searches the messages of a room.
+func (c *Client) ChatSearch(param *ChatSearchRequest) (*ChatSearchResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/chat.search", c.baseURL, c.apiVersion),
+		nil)
+
+	if param.RoomID == "" || param.SearchText == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	url := req.URL.Query()
+	url.Add("roomId", param.RoomID)
+	url.Add("searchText", param.SearchText)
+	if param.Offset != 0 {
+		url.Add("offset", strconv.Itoa(param.Offset))
+	}
+	if param.Count != 0 {
+		url.Add("count", strconv.Itoa(param.Count))
+	}
+	req.URL.RawQuery = url.Encode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := ChatSearchResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 // DeleteMessage deletes an existing chat message.
 func (c *Client) DeleteMessage(param *DeleteMessageRequest) (*DeleteMessageResponse, error) {
 	opt, _ := json.Marshal(param)
@@ -310,3 +555,31 @@ func (c *Client) UnpinMessage(param *SingleMessageId) (*SimpleSuccessResponse, e
 
 	return &res, nil
 }
+
+// GetMessageReadReceipts lists the users who read a message.
+// Read receipts must be enabled on the server.
+func (c *Client) GetMessageReadReceipts(param *SingleMessageId) (*MessageReadReceiptsResponse, error) {
+	if param.MessageId == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/chat.getMessageReadReceipts", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	url.Add("messageId", param.MessageId)
+	req.URL.RawQuery = url.Encode()
+
+	res := MessageReadReceiptsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
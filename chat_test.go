@@ -1,8 +1,10 @@
 package gorocket
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -54,6 +56,29 @@ func TestGetMessage(t *testing.T) {
 	require.True(t, resp.Success)
 }
 
+func TestUpdateMessage(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"message":{"_id":"jC9chsFddTvsbFQG7","rid":"GENERAL","msg":"Approved","ts":"2016-12-14T20:56:05.117Z","u":{"_id":"y65tAmHs93aDChMWu","username":"graywolf336"},"_updatedAt":"2016-12-14T21:56:05.119Z","blocks":[{"type":"section","text":{"type":"mrkdwn","text":"*Approved*"}}]},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UpdateMessage(&UpdateMessageRequest{
+		RoomID: "GENERAL",
+		MsgID:  "jC9chsFddTvsbFQG7",
+		Text:   "Approved",
+		Blocks: NewBlocks().Section(NewMarkdownText("*Approved*")).Build(),
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, "Approved", resp.Message.Msg)
+	require.Equal(t, 1, len(resp.Message.Blocks))
+	require.Equal(t, "*Approved*", resp.Message.Blocks[0].(*SectionBlock).Text.Text)
+
+	_, err = client.UpdateMessage(&UpdateMessageRequest{RoomID: "GENERAL"})
+	require.Error(t, err)
+}
+
 func TestDeleteMessage(t *testing.T) {
 	server := httptest.NewServer(getHandler(t, &HandlerHelper{
 		ResponseBody: `{"_id":"jEnjsxuoDJamGjbH2","ts":1696533809813,"message":{"_id":"jEnjsxuoDJamGjbH2","rid":"6GFJ3tbmHiyHbahmC","u":{"_id":"5fRTXMt7DMJbpPJfh","username":"test.funke","name":"TestFunke"}},"success":true}`,
@@ -174,3 +199,97 @@ func TestUnpinMessage(t *testing.T) {
 
 	require.True(t, resp.Success)
 }
+
+func TestAttachmentActionsValidate(t *testing.T) {
+	valid := []AttachmentAction{
+		NewURLAction("Open runbook", "https://wiki.example.com/runbook"),
+		NewMessageAction("Acknowledge", "ack 42"),
+		{Type: "button", ImageURL: "https://example.com/ack.png", Msg: "ack 42", MsgInChatWindow: true},
+		{Type: "button", Text: "Graph", URL: "https://example.com/graph", IsWebview: true},
+	}
+	for _, action := range valid {
+		require.NoError(t, action.Validate())
+	}
+
+	invalid := []AttachmentAction{
+		{Text: "No type", URL: "https://example.com"},
+		{Type: "button", URL: "https://example.com"},
+		{Type: "button", Text: "Nothing to do"},
+		{Type: "button", Text: "Webview", Msg: "hi", IsWebview: true},
+		{Type: "button", Text: "Chat window", URL: "https://example.com", MsgInChatWindow: true},
+		{Type: "button", Text: "Not sent", Msg: "ack 42"},
+	}
+	for _, action := range invalid {
+		require.Error(t, action.Validate())
+	}
+
+	require.Error(t, Attachment{ButtonAlignment: "diagonal"}.Validate())
+}
+
+func TestPostMessageWithActions(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"message":{"rid":"GENERAL","msg":"Disk full","_id":"LnCSJxxNkCy6K9X8X"},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	msg := Message{
+		RoomID: "GENERAL",
+		Text:   "Disk full",
+		Attachments: []Attachment{{
+			ButtonAlignment: ButtonAlignmentHorizontal,
+			Actions: []AttachmentAction{
+				NewURLAction("Open runbook", "https://wiki.example.com/runbook"),
+				NewMessageAction("Acknowledge", "ack 42"),
+			},
+		}},
+	}
+	resp, err := client.PostMessage(&msg)
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+
+	msg.Attachments[0].Actions = append(msg.Attachments[0].Actions, AttachmentAction{Type: "button", Text: "Broken"})
+	_, err = client.PostMessage(&msg)
+	require.EqualError(t, err, "attachment 0: action 2: action needs an url or a msg")
+}
+
+func TestChatSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/chat.search", r.URL.Path)
+		require.Equal(t, "GENERAL", r.URL.Query().Get("roomId"))
+		require.Equal(t, "deploy", r.URL.Query().Get("searchText"))
+		require.Equal(t, "10", r.URL.Query().Get("count"))
+
+		w.Write([]byte(`{"messages":[{"_id":"Q9HmBTtgaX9KB3ejE","rid":"GENERAL","msg":"deploy is done","ts":"2018-03-01T18:02:26.825Z","u":{"_id":"i5FdM4ssFgAcQP62k","username":"rocket.cat","name":"Rocket Cat"},"mentions":[],"channels":[],"reactions":{":+1:":{"usernames":["john"]}},"_updatedAt":"2018-03-01T18:02:26.828Z"}],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.ChatSearch(&ChatSearchRequest{RoomID: "GENERAL", SearchText: "deploy", Count: 10})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, 1, len(resp.Messages))
+	require.Equal(t, "deploy is done", resp.Messages[0].Msg)
+	require.Equal(t, "Rocket Cat", resp.Messages[0].U.Name)
+	require.Equal(t, []string{"john"}, resp.Messages[0].Reactions[":+1:"].Usernames)
+
+	_, err = client.ChatSearch(&ChatSearchRequest{RoomID: "GENERAL"})
+	require.Error(t, err)
+}
+
+func TestGetMessageReadReceipts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/chat.getMessageReadReceipts", r.URL.Path)
+		require.Equal(t, "m1", r.URL.Query().Get("messageId"))
+
+		w.Write([]byte(`{"receipts":[{"_id":"rc1","roomId":"GENERAL","userId":"u2","messageId":"m1","ts":"2021-01-01T10:00:00.000Z","user":{"_id":"u2","username":"jane","name":"Jane"}}],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.GetMessageReadReceipts(&SingleMessageId{MessageId: "m1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Receipts, 1)
+	require.Equal(t, "jane", resp.Receipts[0].User.Username)
+	require.Equal(t, time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), resp.Receipts[0].Ts)
+}
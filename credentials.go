@@ -0,0 +1,244 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// ErrCredentialsNotRefreshable is returned by CredentialProvider.Refresh when
+// the provider has no way to obtain new credentials.
+var ErrCredentialsNotRefreshable = errors.New("credentials cannot be refreshed")
+
+type Credentials struct {
+	UserID    string `json:"userId"`
+	AuthToken string `json:"authToken"`
+}
+
+// CredentialProvider supplies the credentials used to authenticate requests.
+// It is consulted on each request and must be safe for concurrent use.
+type CredentialProvider interface {
+	// Credentials returns the credentials for the next request.
+	Credentials(ctx context.Context) (Credentials, error)
+	// Refresh is called when the server rejected stale with 401 Unauthorized.
+	// The request is retried once if Refresh returns nil.
+	Refresh(ctx context.Context, stale Credentials) error
+}
+
+// clientBinder is implemented by providers that need the client to log in.
+type clientBinder interface {
+	bind(c *Client)
+}
+
+// credentialSetter is implemented by providers that follow Login and
+// RotatePersonalAccessToken.
+type credentialSetter interface {
+	setCredentials(creds Credentials)
+	setAuthToken(token string)
+}
+
+// StaticCredentials provides a fixed user id and auth token.
+type StaticCredentials struct {
+	mu    sync.RWMutex
+	creds Credentials
+}
+
+// NewStaticCredentials creates a provider with the given user id and auth token.
+func NewStaticCredentials(userID, authToken string) *StaticCredentials {
+	return &StaticCredentials{
+		creds: Credentials{UserID: userID, AuthToken: authToken},
+	}
+}
+
+// NewPersonalAccessTokenCredentials creates a provider for a personal access token.
+func NewPersonalAccessTokenCredentials(userID, token string) *StaticCredentials {
+	return NewStaticCredentials(userID, token)
+}
+
+func (s *StaticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.creds, nil
+}
+
+func (s *StaticCredentials) Refresh(ctx context.Context, stale Credentials) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// credentials were replaced after the request was sent
+	if s.creds != stale {
+		return nil
+	}
+
+	return ErrCredentialsNotRefreshable
+}
+
+func (s *StaticCredentials) setCredentials(creds Credentials) {
+	s.mu.Lock()
+	s.creds = creds
+	s.mu.Unlock()
+}
+
+func (s *StaticCredentials) setAuthToken(token string) {
+	s.mu.Lock()
+	s.creds.AuthToken = token
+	s.mu.Unlock()
+}
+
+// EnvCredentials reads the user id and auth token from environment variables.
+type EnvCredentials struct {
+	UserIDVar    string
+	AuthTokenVar string
+}
+
+// NewEnvCredentials creates a provider reading ROCKETCHAT_USER_ID and
+// ROCKETCHAT_AUTH_TOKEN.
+func NewEnvCredentials() *EnvCredentials {
+	return &EnvCredentials{
+		UserIDVar:    "ROCKETCHAT_USER_ID",
+		AuthTokenVar: "ROCKETCHAT_AUTH_TOKEN",
+	}
+}
+
+func (e *EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials{
+		UserID:    os.Getenv(e.UserIDVar),
+		AuthToken: os.Getenv(e.AuthTokenVar),
+	}, nil
+}
+
+func (e *EnvCredentials) Refresh(ctx context.Context, stale Credentials) error {
+	creds, _ := e.Credentials(ctx)
+	if creds != stale {
+		return nil
+	}
+
+	return ErrCredentialsNotRefreshable
+}
+
+// FileCredentials reads the credentials from a JSON file
+// like {"userId": "...", "authToken": "..."}.
+type FileCredentials struct {
+	Path string
+}
+
+// NewFileCredentials creates a provider reading the given file.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{Path: path}
+}
+
+func (f *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	creds := Credentials{}
+
+	b, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return creds, err
+	}
+
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return creds, fmt.Errorf("parse credentials file %s: %w", f.Path, err)
+	}
+
+	return creds, nil
+}
+
+func (f *FileCredentials) Refresh(ctx context.Context, stale Credentials) error {
+	creds, err := f.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if creds != stale {
+		return nil
+	}
+
+	return ErrCredentialsNotRefreshable
+}
+
+// PasswordCredentials logs in with a username and password and logs in
+// again when the auth token expires.
+type PasswordCredentials struct {
+	// TwoFactorCode answers the two-factor challenge, if the user has one.
+	TwoFactorCode TwoFactorCodeFunc
+
+	user     string
+	password string
+
+	client *Client
+
+	mu    sync.Mutex
+	creds Credentials
+}
+
+// NewPasswordCredentials creates a provider logging in as user.
+func NewPasswordCredentials(user, password string) *PasswordCredentials {
+	return &PasswordCredentials{
+		user:     user,
+		password: password,
+	}
+}
+
+func (p *PasswordCredentials) bind(c *Client) {
+	p.client = c
+}
+
+func (p *PasswordCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds.AuthToken == "" {
+		if err := p.login(ctx); err != nil {
+			return Credentials{}, err
+		}
+	}
+
+	return p.creds, nil
+}
+
+func (p *PasswordCredentials) Refresh(ctx context.Context, stale Credentials) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// another request has already logged in again
+	if p.creds != stale {
+		return nil
+	}
+
+	return p.login(ctx)
+}
+
+func (p *PasswordCredentials) login(ctx context.Context) error {
+	if p.client == nil {
+		return fmt.Errorf("password credentials are not bound to a client")
+	}
+
+	res, err := p.client.login(ctx, &LoginPayload{User: p.user, Password: p.password}, p.TwoFactorCode)
+	if err != nil {
+		return err
+	}
+
+	if res.Status != "success" {
+		return fmt.Errorf("login as %s failed: %s", p.user, res.Message)
+	}
+
+	p.creds = Credentials{UserID: res.Data.UserID, AuthToken: res.Data.AuthToken}
+
+	return nil
+}
+
+func (p *PasswordCredentials) setCredentials(creds Credentials) {
+	p.mu.Lock()
+	p.creds = creds
+	p.mu.Unlock()
+}
+
+func (p *PasswordCredentials) setAuthToken(token string) {
+	p.mu.Lock()
+	p.creds.AuthToken = token
+	p.mu.Unlock()
+}
@@ -0,0 +1,186 @@
+package gorocket
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "user", r.Header.Get("X-User-Id"))
+		require.Equal(t, "token", r.Header.Get("X-Auth-Token"))
+		_, err := w.Write([]byte(`{"_id":"user","username":"example","success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewStaticCredentials("user", "token")))
+	resp, err := client.Me()
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestStaticCredentialsUnauthorized(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, err := w.Write([]byte(`{"status":"error","message":"You must be logged in to do this."}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewStaticCredentials("user", "expired")))
+	resp, err := client.Me()
+	require.NoError(t, err)
+	require.False(t, resp.Success)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("ROCKETCHAT_USER_ID", "env-user")
+	os.Setenv("ROCKETCHAT_AUTH_TOKEN", "env-token")
+	defer os.Unsetenv("ROCKETCHAT_USER_ID")
+	defer os.Unsetenv("ROCKETCHAT_AUTH_TOKEN")
+
+	creds, err := NewEnvCredentials().Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "env-user", creds.UserID)
+	require.Equal(t, "env-token", creds.AuthToken)
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "credentials.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"userId":"file-user","authToken":"file-token"}`), 0600))
+
+	provider := NewFileCredentials(path)
+	creds, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "file-user", creds.UserID)
+	require.Equal(t, "file-token", creds.AuthToken)
+
+	require.ErrorIs(t, provider.Refresh(context.Background(), creds), ErrCredentialsNotRefreshable)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"userId":"file-user","authToken":"rotated"}`), 0600))
+	require.NoError(t, provider.Refresh(context.Background(), creds))
+}
+
+func TestPasswordCredentialsRelogin(t *testing.T) {
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/login" {
+			n := atomic.AddInt32(&logins, 1)
+			_, err := fmt.Fprintf(w, `{"status":"success","data":{"userId":"user","authToken":"token-%d"}}`, n)
+			require.NoError(t, err)
+			return
+		}
+
+		// the first issued token has expired
+		if r.Header.Get("X-Auth-Token") == "token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write([]byte(`{"status":"error","message":"You must be logged in to do this."}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"_id":"user","username":"example","success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewPasswordCredentials("example", "password")))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Me()
+			require.NoError(t, err)
+			require.True(t, resp.Success)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&logins))
+}
+
+func TestPasswordCredentialsLoginFailed(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		Code:         http.StatusUnauthorized,
+		ResponseBody: `{"status":"error","error":"Unauthorized","message":"Unauthorized"}`,
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewPasswordCredentials("example", "wrong")))
+	_, err := client.Me()
+	require.Error(t, err)
+}
+
+func TestCredentialsErrorResetsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.URL.Query().Get("count"))
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	missing := NewWithOptions(server.URL, WithCredentialProvider(NewFileCredentials(filepath.Join(os.TempDir(), "gorocket-missing.json"))))
+	_, err := missing.Count(10).ChannelList()
+	require.Error(t, err)
+
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("token"))
+	_, err = client.ChannelList()
+	require.NoError(t, err)
+}
+
+func TestLoginWithPasswordCredentials(t *testing.T) {
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/login" {
+			atomic.AddInt32(&logins, 1)
+			_, err := w.Write([]byte(`{"status":"success","data":{"userId":"admin","authToken":"admin-token"}}`))
+			require.NoError(t, err)
+			return
+		}
+
+		require.Equal(t, "admin", r.Header.Get("X-User-Id"))
+		require.Equal(t, "admin-token", r.Header.Get("X-Auth-Token"))
+		_, err := w.Write([]byte(`{"_id":"admin","username":"admin","success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewPasswordCredentials("example", "password")))
+	_, err := client.Login(&LoginPayload{User: "admin", Password: "secret"})
+	require.NoError(t, err)
+
+	resp, err := client.Me()
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
+
+func TestLoginWithEnvCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewEnvCredentials()))
+	_, err := client.Login(&LoginPayload{User: "admin", Password: "secret"})
+	require.EqualError(t, err, "login not sent: the credential provider cannot take new credentials")
+}
@@ -0,0 +1,126 @@
+package gorocket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// soundContentTypes are the audio types missing from the mime package defaults.
+var soundContentTypes = map[string]string{
+	"mp3": "audio/mpeg",
+	"wav": "audio/wav",
+	"ogg": "audio/ogg",
+}
+
+type CustomSound struct {
+	ID        string `json:"_id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+}
+
+type CustomSoundsResponse struct {
+	Sounds  []CustomSound `json:"sounds"`
+	Offset  int           `json:"offset"`
+	Count   int           `json:"count"`
+	Total   int           `json:"total"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+}
+
+type CustomSoundRequest struct {
+	Name string
+	// Sound is the audio file, its extension is taken from FileName
+	Sound    io.Reader
+	FileName string
+}
+
+// CustomSoundsList lists the custom sounds.
+func (c *Client) CustomSoundsList() (*CustomSoundsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/custom-sounds.list", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := CustomSoundsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CustomSoundsCreate uploads a new custom sound. The server has no REST
+// endpoint for it, the insertOrUpdateSound and uploadCustomSound methods are
+// called instead. The inserted sound is deleted again if the upload fails.
+func (c *Client) CustomSoundsCreate(param *CustomSoundRequest) (*CustomSound, error) {
+	extension := strings.TrimPrefix(filepath.Ext(param.FileName), ".")
+	if param.Name == "" || param.Sound == nil || extension == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	content, err := ioutil.ReadAll(param.Sound)
+	if err != nil {
+		return nil, err
+	}
+
+	sound := CustomSound{Name: param.Name, Extension: extension}
+
+	res, err := c.CallMethod(context.Background(), "insertOrUpdateSound", map[string]interface{}{
+		"name":      sound.Name,
+		"extension": sound.Extension,
+		"newFile":   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Decode(&sound.ID); err != nil {
+		return nil, err
+	}
+
+	contentType := soundContentTypes[strings.ToLower(extension)]
+	if contentType == "" {
+		contentType = mime.TypeByExtension("." + extension)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// the method takes the file as a binary string, one char per byte
+	binary := make([]rune, len(content))
+	for i, b := range content {
+		binary[i] = rune(b)
+	}
+
+	_, err = c.CallMethod(context.Background(), "uploadCustomSound", string(binary), contentType, map[string]interface{}{
+		"_id":       sound.ID,
+		"name":      sound.Name,
+		"extension": sound.Extension,
+	})
+	if err != nil {
+		// don't leave a sound without a file behind
+		c.CustomSoundsDelete(sound.ID)
+		return nil, err
+	}
+
+	return &sound, nil
+}
+
+// CustomSoundsDelete deletes a custom sound with the deleteCustomSound method.
+func (c *Client) CustomSoundsDelete(soundID string) error {
+	if soundID == "" {
+		return fmt.Errorf("false parameters")
+	}
+
+	_, err := c.CallMethod(context.Background(), "deleteCustomSound", soundID)
+	return err
+}
@@ -0,0 +1,107 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomSoundsList(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"sounds":[{"_id":"s1","name":"ding","extension":"mp3"}],"count":1,"offset":0,"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.CustomSoundsList()
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, CustomSound{ID: "s1", Name: "ding", Extension: "mp3"}, resp.Sounds[0])
+}
+
+func TestCustomSoundsCreate(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		call := methodCall{}
+		require.NoError(t, json.Unmarshal([]byte(body["message"]), &call))
+		require.Equal(t, "/api/v1/method.call/"+call.Method, r.URL.Path)
+		methods = append(methods, call.Method)
+
+		var result interface{}
+		switch call.Method {
+		case "insertOrUpdateSound":
+			require.Equal(t, []interface{}{map[string]interface{}{"name": "ding", "extension": "mp3", "newFile": true}}, call.Params)
+			result = "s1"
+		case "uploadCustomSound":
+			require.Equal(t, []interface{}{"ID3\u00ff", "audio/mpeg", map[string]interface{}{"_id": "s1", "name": "ding", "extension": "mp3"}}, call.Params)
+		}
+
+		message, _ := json.Marshal(map[string]interface{}{"msg": "result", "id": call.ID, "result": result})
+		json.NewEncoder(w).Encode(map[string]interface{}{"message": string(message), "success": true})
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	sound, err := client.CustomSoundsCreate(&CustomSoundRequest{Name: "ding", Sound: strings.NewReader("ID3\xff"), FileName: "ding.mp3"})
+	require.NoError(t, err)
+	require.Equal(t, &CustomSound{ID: "s1", Name: "ding", Extension: "mp3"}, sound)
+	require.Equal(t, []string{"insertOrUpdateSound", "uploadCustomSound"}, methods)
+
+	_, err = client.CustomSoundsCreate(&CustomSoundRequest{Name: "ding", Sound: strings.NewReader("ID3"), FileName: "ding"})
+	require.Error(t, err)
+}
+
+func TestCustomSoundsDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/method.call/deleteCustomSound", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		call := methodCall{}
+		require.NoError(t, json.Unmarshal([]byte(body["message"]), &call))
+		require.Equal(t, []interface{}{"s1"}, call.Params)
+
+		w.Write([]byte(`{"message":"{\"msg\":\"result\",\"id\":\"` + call.ID + `\",\"result\":true}","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	require.NoError(t, client.CustomSoundsDelete("s1"))
+}
+
+func TestCustomSoundsCreateUploadFailed(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		call := methodCall{}
+		require.NoError(t, json.Unmarshal([]byte(body["message"]), &call))
+		methods = append(methods, call.Method)
+
+		result := map[string]interface{}{"msg": "result", "id": call.ID}
+		switch call.Method {
+		case "insertOrUpdateSound":
+			result["result"] = "s1"
+		case "uploadCustomSound":
+			result["error"] = map[string]interface{}{"error": "error-file-too-large", "reason": "File is too large"}
+		case "deleteCustomSound":
+			require.Equal(t, []interface{}{"s1"}, call.Params)
+			result["result"] = true
+		}
+
+		message, _ := json.Marshal(result)
+		json.NewEncoder(w).Encode(map[string]interface{}{"message": string(message), "success": true})
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	_, err := client.CustomSoundsCreate(&CustomSoundRequest{Name: "ding", Sound: strings.NewReader("ID3"), FileName: "ding.mp3"})
+	require.EqualError(t, err, "File is too large [error-file-too-large]")
+	require.Equal(t, []string{"insertOrUpdateSound", "uploadCustomSound", "deleteCustomSound"}, methods)
+}
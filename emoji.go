@@ -0,0 +1,172 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type CustomEmoji struct {
+	ID        string    `json:"_id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	Extension string    `json:"extension"`
+	UpdatedAt time.Time `json:"_updatedAt"`
+}
+
+type CustomEmojiListResponse struct {
+	Emojis struct {
+		Update []CustomEmoji `json:"update"`
+		Remove []CustomEmoji `json:"remove"`
+	} `json:"emojis"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type CustomEmojiAllResponse struct {
+	Emojis  []CustomEmoji `json:"emojis"`
+	Offset  int           `json:"offset"`
+	Count   int           `json:"count"`
+	Total   int           `json:"total"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+}
+
+type CustomEmojiRequest struct {
+	Name    string
+	Aliases []string
+	// Image is the emoji image, required to create an emoji. The extension
+	// of FileName sets the image type.
+	Image    io.Reader
+	FileName string
+}
+
+// EmojiCustomList lists the custom emojis. If updatedSince is set, only the
+// emojis changed and removed since then are returned.
+func (c *Client) EmojiCustomList(updatedSince time.Time) (*CustomEmojiListResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/emoji-custom.list", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !updatedSince.IsZero() {
+		url := req.URL.Query()
+		url.Add("updatedSince", updatedSince.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := CustomEmojiListResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// EmojiCustomAll lists the custom emojis page by page.
+func (c *Client) EmojiCustomAll() (*CustomEmojiAllResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/emoji-custom.all", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := CustomEmojiAllResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// EmojiCustomCreate uploads a new custom emoji.
+func (c *Client) EmojiCustomCreate(param *CustomEmojiRequest) (*SimpleSuccessResponse, error) {
+	if param.Name == "" || param.Image == nil || param.FileName == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := newMultipartRequest(
+		fmt.Sprintf("%s/%s/emoji-custom.create", c.baseURL, c.apiVersion),
+		map[string]string{
+			"name":    param.Name,
+			"aliases": strings.Join(param.Aliases, ","),
+		},
+		"emoji", param.FileName, param.Image)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// EmojiCustomUpdate updates the name and aliases of a custom emoji, and its
+// image if param.Image is set.
+func (c *Client) EmojiCustomUpdate(emojiID string, param *CustomEmojiRequest) (*SimpleSuccessResponse, error) {
+	if emojiID == "" || param.Name == "" || (param.Image != nil && param.FileName == "") {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := newMultipartRequest(
+		fmt.Sprintf("%s/%s/emoji-custom.update", c.baseURL, c.apiVersion),
+		map[string]string{
+			"_id":     emojiID,
+			"name":    param.Name,
+			"aliases": strings.Join(param.Aliases, ","),
+		},
+		"emoji", param.FileName, param.Image)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// EmojiCustomDelete deletes a custom emoji.
+func (c *Client) EmojiCustomDelete(emojiID string) (*SimpleSuccessResponse, error) {
+	if emojiID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{"emojiId": emojiID})
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/emoji-custom.delete", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
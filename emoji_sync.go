@@ -0,0 +1,245 @@
+package gorocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EmojiSyncOptions configures SyncEmojiDir.
+type EmojiSyncOptions struct {
+	// AliasesFile maps emoji names to their aliases, as YAML or JSON.
+	// Defaults to aliases.yaml in the synced directory; a missing file means
+	// no aliases.
+	AliasesFile string
+	// Prune deletes the custom emojis that have no image in the directory.
+	Prune bool
+}
+
+// EmojiSyncResult lists the emoji names by what SyncEmojiDir did to them.
+type EmojiSyncResult struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+}
+
+var emojiExtensions = map[string]bool{
+	".png":  true,
+	".gif":  true,
+	".jpg":  true,
+	".jpeg": true,
+}
+
+type localEmoji struct {
+	name      string
+	path      string
+	extension string
+	aliases   []string
+}
+
+// LoadEmojiAliases reads a YAML or JSON file mapping emoji names to aliases.
+func LoadEmojiAliases(path string) (map[string][]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := map[string][]string{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(b, &aliases)
+	default:
+		err = yaml.Unmarshal(b, &aliases)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse emoji aliases %s: %w", path, err)
+	}
+
+	return aliases, nil
+}
+
+// SyncEmojiDir mirrors the images in dir to the server's custom emojis. Each
+// png, gif or jpeg file becomes an emoji named after the file without its
+// extension. Emojis whose image, extension or aliases differ are updated.
+func (c *Client) SyncEmojiDir(dir string, opts *EmojiSyncOptions) (*EmojiSyncResult, error) {
+	if opts == nil {
+		opts = &EmojiSyncOptions{}
+	}
+
+	local, err := readEmojiDir(dir, opts.AliasesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.EmojiCustomList(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !list.Success {
+		return nil, fmt.Errorf("list custom emojis: %s", list.Error)
+	}
+
+	remote := map[string]CustomEmoji{}
+	for _, e := range list.Emojis.Update {
+		remote[e.Name] = e
+	}
+
+	result := EmojiSyncResult{}
+
+	for _, e := range local {
+		image, err := ioutil.ReadFile(e.path)
+		if err != nil {
+			return nil, err
+		}
+
+		param := &CustomEmojiRequest{
+			Name:     e.name,
+			Aliases:  e.aliases,
+			Image:    bytes.NewReader(image),
+			FileName: filepath.Base(e.path),
+		}
+
+		current, ok := remote[e.name]
+		if !ok {
+			res, err := c.EmojiCustomCreate(param)
+			if err := checkImport(res, err, "emoji", e.name); err != nil {
+				return nil, err
+			}
+			result.Created = append(result.Created, e.name)
+			continue
+		}
+
+		changed, err := c.emojiChanged(current, e, image)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			result.Unchanged = append(result.Unchanged, e.name)
+			continue
+		}
+
+		res, err := c.EmojiCustomUpdate(current.ID, param)
+		if err := checkImport(res, err, "emoji", e.name); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, e.name)
+	}
+
+	if opts.Prune {
+		names := make([]string, 0, len(remote))
+		for name := range remote {
+			if _, ok := local[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			res, err := c.EmojiCustomDelete(remote[name].ID)
+			if err := checkImport(res, err, "emoji", name); err != nil {
+				return nil, err
+			}
+			result.Deleted = append(result.Deleted, name)
+		}
+	}
+
+	sort.Strings(result.Created)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Unchanged)
+
+	return &result, nil
+}
+
+func readEmojiDir(dir, aliasesFile string) (map[string]localEmoji, error) {
+	if aliasesFile == "" {
+		aliasesFile = filepath.Join(dir, "aliases.yaml")
+	}
+
+	aliases, err := LoadEmojiAliases(aliasesFile)
+	if os.IsNotExist(err) {
+		aliases = map[string][]string{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	local := map[string]localEmoji{}
+	for _, f := range files {
+		ext := strings.ToLower(filepath.Ext(f.Name()))
+		if f.IsDir() || !emojiExtensions[ext] {
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		if prev, ok := local[name]; ok {
+			return nil, fmt.Errorf("emoji %s: both %s and %s exist", name, filepath.Base(prev.path), f.Name())
+		}
+
+		local[name] = localEmoji{
+			name:      name,
+			path:      filepath.Join(dir, f.Name()),
+			extension: strings.TrimPrefix(ext, "."),
+			aliases:   sortedStrings(aliases[name]),
+		}
+	}
+
+	return local, nil
+}
+
+// emojiChanged reports whether the server emoji differs from the local one.
+// The image is compared with the one served by the server, which is treated
+// as changed if it cannot be downloaded.
+func (c *Client) emojiChanged(current CustomEmoji, e localEmoji, image []byte) (bool, error) {
+	if current.Extension != e.extension ||
+		strings.Join(sortedStrings(current.Aliases), ",") != strings.Join(e.aliases, ",") {
+		return true, nil
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/emoji-custom/%s.%s", c.baseURL, url.PathEscape(current.Name), current.Extension),
+		nil)
+	if err != nil {
+		return false, err
+	}
+
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return true, nil
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return true, nil
+	}
+
+	served, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return true, nil
+	}
+
+	return !bytes.Equal(served, image), nil
+}
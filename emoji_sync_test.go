@@ -0,0 +1,98 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncEmojiDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"party.gif":    "party-v1",
+		"wave.png":     "wave-v2",
+		"shrug.png":    "shrug",
+		"notes.txt":    "not an emoji",
+		"aliases.yaml": "party: [tada]\nshrug: [idk, meh]\n",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	var created, updated, deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/emoji-custom.list":
+			w.Write([]byte(`{"emojis":{"update":[
+				{"_id":"e1","name":"party","aliases":["tada"],"extension":"gif"},
+				{"_id":"e2","name":"wave","extension":"png"},
+				{"_id":"e3","name":"shrug","aliases":["idk"],"extension":"png"},
+				{"_id":"e4","name":"old","extension":"png"}
+			],"remove":[]},"success":true}`))
+		case "/emoji-custom/party.gif":
+			w.Write([]byte("party-v1"))
+		case "/emoji-custom/wave.png":
+			w.Write([]byte("wave-v1"))
+		case "/api/v1/emoji-custom.create":
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			created = append(created, r.FormValue("name"))
+			w.Write([]byte(`{"success":true}`))
+		case "/api/v1/emoji-custom.update":
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			updated = append(updated, r.FormValue("_id")+":"+r.FormValue("aliases"))
+			w.Write([]byte(`{"success":true}`))
+		case "/api/v1/emoji-custom.delete":
+			body := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			deleted = append(deleted, body["emojiId"])
+			w.Write([]byte(`{"success":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	result, err := client.SyncEmojiDir(dir, &EmojiSyncOptions{Prune: true})
+	require.NoError(t, err)
+	require.Equal(t, &EmojiSyncResult{
+		Updated:   []string{"shrug", "wave"},
+		Deleted:   []string{"old"},
+		Unchanged: []string{"party"},
+	}, result)
+	require.Empty(t, created)
+	require.ElementsMatch(t, []string{"e2:", "e3:idk,meh"}, updated)
+	require.Equal(t, []string{"e4"}, deleted)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "party.gif")))
+	result, err = client.SyncEmojiDir(dir, nil)
+	require.NoError(t, err)
+	require.Empty(t, result.Deleted)
+}
+
+func TestEmojiChangedNotDownloaded(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewWithOptions(server.URL, WithTimeout(50*time.Millisecond))
+	changed, err := client.emojiChanged(
+		CustomEmoji{Name: "party", Extension: "gif"},
+		localEmoji{name: "party", extension: "gif"},
+		[]byte("party"))
+	require.NoError(t, err)
+	require.True(t, changed)
+}
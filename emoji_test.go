@@ -0,0 +1,116 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiCustomList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/emoji-custom.list", r.URL.Path)
+		require.Equal(t, "2021-01-01T10:00:00Z", r.URL.Query().Get("updatedSince"))
+
+		w.Write([]byte(`{"emojis":{"update":[{"_id":"e1","name":"party","aliases":["tada"],"extension":"gif","_updatedAt":"2021-01-02T10:00:00.000Z"}],"remove":[{"_id":"e2","name":"old"}]},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.EmojiCustomList(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, resp.Emojis.Update, 1)
+	require.Equal(t, []string{"tada"}, resp.Emojis.Update[0].Aliases)
+	require.Equal(t, "gif", resp.Emojis.Update[0].Extension)
+	require.Equal(t, "old", resp.Emojis.Remove[0].Name)
+}
+
+func TestEmojiCustomAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/emoji-custom.all", r.URL.Path)
+		require.Equal(t, "50", r.URL.Query().Get("count"))
+
+		w.Write([]byte(`{"emojis":[{"_id":"e1","name":"party","extension":"gif"}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.Count(50).EmojiCustomAll()
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "party", resp.Emojis[0].Name)
+}
+
+func TestEmojiCustomCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/emoji-custom.create", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "party", r.FormValue("name"))
+		require.Equal(t, "tada,confetti", r.FormValue("aliases"))
+
+		file, header, err := r.FormFile("emoji")
+		require.NoError(t, err)
+		defer file.Close()
+		require.Equal(t, "party.gif", header.Filename)
+		b, _ := ioutil.ReadAll(file)
+		require.Equal(t, "GIF89a", string(b))
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.EmojiCustomCreate(&CustomEmojiRequest{
+		Name:     "party",
+		Aliases:  []string{"tada", "confetti"},
+		Image:    strings.NewReader("GIF89a"),
+		FileName: "party.gif",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+
+	_, err = client.EmojiCustomCreate(&CustomEmojiRequest{Name: "party"})
+	require.Error(t, err)
+}
+
+func TestEmojiCustomUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/emoji-custom.update", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "e1", r.FormValue("_id"))
+		require.Equal(t, "party", r.FormValue("name"))
+		require.Empty(t, r.MultipartForm.File)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.EmojiCustomUpdate("e1", &CustomEmojiRequest{Name: "party"})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestEmojiCustomDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/emoji-custom.delete", r.URL.Path)
+		require.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]string{"emojiId": "e1"}, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.EmojiCustomDelete("e1")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
@@ -4,5 +4,8 @@ go 1.13
 
 require (
 	github.com/google/go-querystring v1.1.0
+	github.com/gorilla/websocket v1.4.2
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 )
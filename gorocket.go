@@ -2,9 +2,14 @@ package gorocket
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -12,11 +17,16 @@ import (
 
 type Client struct {
 	baseURL    string
-	userID     string
-	xToken     string
 	apiVersion string
 	HTTPClient *http.Client
 
+	// mu guards userID and xToken
+	mu     sync.RWMutex
+	userID string
+	xToken string
+
+	provider CredentialProvider
+
 	timeout time.Duration
 }
 
@@ -26,7 +36,23 @@ type PaginationStruct struct {
 	Sort   string `url:"sort,omitempty"`
 }
 
-var pagination PaginationStruct
+var (
+	pagination   PaginationStruct
+	paginationMu sync.Mutex
+)
+
+// CustomFields holds the custom fields of a user or a room.
+type CustomFields map[string]interface{}
+
+// Decode decodes custom fields into the value pointed to by v.
+func (f CustomFields) Decode(v interface{}) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
 
 // NewClient creates new rocket.chat client with given API key
 func NewClient(url string) *Client {
@@ -78,11 +104,25 @@ func WithXToken(xtoken string) Option {
 	}
 }
 
+// WithCredentialProvider sets the provider asked for the credentials of every
+// request. It takes precedence over WithUserID and WithXToken. Login and
+// RotatePersonalAccessToken pass the new credentials to StaticCredentials and
+// PasswordCredentials, and fail with providers that cannot take them, like
+// EnvCredentials and FileCredentials.
+func WithCredentialProvider(p CredentialProvider) Option {
+	return func(c *Client) {
+		if b, ok := p.(clientBinder); ok {
+			b.bind(c)
+		}
+		c.provider = p
+	}
+}
+
 func (c *Client) sendRequest(req *http.Request, v interface{}) error {
 	req.Header.Set("Accept", "application/json; charset=utf-8")
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Add("X-Auth-Token", c.xToken)
-	req.Header.Add("X-User-Id", c.userID)
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
 
 	if c.timeout > 0 {
 		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
@@ -91,13 +131,59 @@ func (c *Client) sendRequest(req *http.Request, v interface{}) error {
 		req = req.WithContext(ctx)
 	}
 
-	res, err := c.HTTPClient.Do(c.addQueryParams(req))
+	req = c.addQueryParams(req)
+	defer c.cleanup()
+
+	creds, err := c.requestCredentials(req.Context())
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(req, &creds)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	c.cleanup()
+	if res.StatusCode == http.StatusUnauthorized && c.provider != nil {
+		retry, err := c.reauthenticate(req, creds)
+		if err != nil {
+			res.Body.Close()
+			return err
+		}
+		if retry != nil {
+			res.Body.Close()
+			res = retry
+		}
+	}
+
+	defer res.Body.Close()
+
+	resp := v
+	if err = json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sendAnonymous sends a request without authentication headers.
+func (c *Client) sendAnonymous(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
+	res, err := c.do(req, nil)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
 
 	defer res.Body.Close()
 
@@ -109,12 +195,114 @@ func (c *Client) sendRequest(req *http.Request, v interface{}) error {
 	return nil
 }
 
+// do sends a copy of req, so the same request can be sent again on retry.
+func (c *Client) do(req *http.Request, creds *Credentials) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	if creds != nil {
+		r.Header.Set("X-Auth-Token", creds.AuthToken)
+		r.Header.Set("X-User-Id", creds.UserID)
+	}
+
+	return c.HTTPClient.Do(r)
+}
+
+// reauthenticate refreshes the rejected credentials and sends req once more.
+// It returns a nil response if the credentials cannot be refreshed.
+func (c *Client) reauthenticate(req *http.Request, stale Credentials) (*http.Response, error) {
+	if err := c.provider.Refresh(req.Context(), stale); err != nil {
+		if errors.Is(err, ErrCredentialsNotRefreshable) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("refresh credentials: %w", err)
+	}
+
+	creds, err := c.requestCredentials(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.do(req, &creds)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// requestCredentials returns the credentials for the next request.
+func (c *Client) requestCredentials(ctx context.Context) (Credentials, error) {
+	if c.provider != nil {
+		return c.provider.Credentials(ctx)
+	}
+
+	userID, xToken := c.credentials()
+
+	return Credentials{UserID: userID, AuthToken: xToken}, nil
+}
+
+// credentials returns the current user id and auth token.
+func (c *Client) credentials() (string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.userID, c.xToken
+}
+
+// setCredentials replaces the user id and auth token used for requests.
+func (c *Client) setCredentials(userID, xToken string) {
+	c.mu.Lock()
+	c.userID = userID
+	c.xToken = xToken
+	c.mu.Unlock()
+
+	if s, ok := c.provider.(credentialSetter); ok {
+		s.setCredentials(Credentials{UserID: userID, AuthToken: xToken})
+	}
+}
+
+// setAuthToken replaces the auth token used for requests and keeps the user id.
+func (c *Client) setAuthToken(xToken string) {
+	c.mu.Lock()
+	c.xToken = xToken
+	c.mu.Unlock()
+
+	if s, ok := c.provider.(credentialSetter); ok {
+		s.setAuthToken(xToken)
+	}
+}
+
+// canSetAuthToken reports whether setCredentials and setAuthToken change the
+// credentials used for requests.
+func (c *Client) canSetAuthToken() bool {
+	if c.provider == nil {
+		return true
+	}
+
+	_, ok := c.provider.(credentialSetter)
+	return ok
+}
+
 func (c *Client) Count(val int) *Client {
+	paginationMu.Lock()
+	defer paginationMu.Unlock()
+
 	pagination.Count = val
 	return c
 }
 
 func (c *Client) Offset(val int) *Client {
+	paginationMu.Lock()
+	defer paginationMu.Unlock()
+
 	pagination.Offset = val
 	return c
 }
@@ -125,13 +313,18 @@ func (c *Client) Sort(val map[string]int) *Client {
 		log.Printf("cant create sort. error: %s", err)
 		return c
 	}
+	paginationMu.Lock()
 	pagination.Sort = string(byteJson)
+	paginationMu.Unlock()
 
 	return c
 }
 
 func (c *Client) addQueryParams(req *http.Request) *http.Request {
+	paginationMu.Lock()
 	v, err := query.Values(pagination)
+	paginationMu.Unlock()
+
 	if err != nil {
 		log.Printf("error create query string: %s", err)
 		return req
@@ -145,7 +338,20 @@ func (c *Client) addQueryParams(req *http.Request) *http.Request {
 }
 
 func (c *Client) cleanup() {
+	paginationMu.Lock()
+	defer paginationMu.Unlock()
+
 	pagination.Sort = ""
 	pagination.Offset = 0
 	pagination.Count = 0
 }
+
+// randomID returns a random hex string usable as an id or a token.
+func randomID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -84,3 +84,25 @@ func TestClientWithOptions(t *testing.T) {
 		t.Errorf("Expected API version to be api/v1, got %s", client.apiVersion)
 	}
 }
+
+func TestCustomFieldsDecode(t *testing.T) {
+	fields := CustomFields{
+		"employeeId": "E-1024",
+		"costCentre": "R&D",
+		"level":      3,
+	}
+
+	var dst struct {
+		EmployeeID string `json:"employeeId"`
+		CostCentre string `json:"costCentre"`
+		Level      int    `json:"level"`
+	}
+
+	if err := fields.Decode(&dst); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if dst.EmployeeID != "E-1024" || dst.CostCentre != "R&D" || dst.Level != 3 {
+		t.Errorf("Unexpected decoded custom fields: %+v", dst)
+	}
+}
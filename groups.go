@@ -30,9 +30,10 @@ type GroupCountersResponse struct {
 }
 
 type CreateGroupRequest struct {
-	Name     string   `json:"name"`
-	Members  []string `json:"members,omitempty"`
-	ReadOnly bool     `json:"readOnly,omitempty"`
+	Name         string       `json:"name"`
+	Members      []string     `json:"members,omitempty"`
+	ReadOnly     bool         `json:"readOnly,omitempty"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
 }
 
 type CreateGroupResponse struct {
@@ -51,22 +52,21 @@ type GroupInfoResponse struct {
 }
 
 type groupInfo struct {
-	ID           string `json:"_id"`
-	Name         string `json:"name"`
-	Fname        string `json:"fname"`
-	T            string `json:"t"`
-	Msgs         int    `json:"msgs"`
-	UsersCount   int    `json:"usersCount"`
-	U            UChat  `json:"u"`
-	CustomFields struct {
-	} `json:"customFields"`
-	Broadcast bool      `json:"broadcast"`
-	Encrypted bool      `json:"encrypted"`
-	Ts        time.Time `json:"ts"`
-	Ro        bool      `json:"ro"`
-	Default   bool      `json:"default"`
-	SysMes    bool      `json:"sysMes"`
-	UpdatedAt time.Time `json:"_updatedAt"`
+	ID           string       `json:"_id"`
+	Name         string       `json:"name"`
+	Fname        string       `json:"fname"`
+	T            string       `json:"t"`
+	Msgs         int          `json:"msgs"`
+	UsersCount   int          `json:"usersCount"`
+	U            UChat        `json:"u"`
+	CustomFields CustomFields `json:"customFields"`
+	Broadcast    bool         `json:"broadcast"`
+	Encrypted    bool         `json:"encrypted"`
+	Ts           time.Time    `json:"ts"`
+	Ro           bool         `json:"ro"`
+	Default      bool         `json:"default"`
+	SysMes       bool         `json:"sysMes"`
+	UpdatedAt    time.Time    `json:"_updatedAt"`
 }
 
 type InviteGroupRequest struct {
@@ -2,59 +2,96 @@ package gorocket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"io/ioutil"
 	"net/http"
 )
 
-type HookMessage struct {
-	Text        string           `json:"text"`
-	Attachments []HookAttachment `json:"attachments"`
+// HookMessage is the payload of an incoming webhook. It accepts the same
+// fields as a chat message, Channel overrides the channel of the webhook.
+type HookMessage = Message
+
+type HookAttachment = Attachment
+
+type HookResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
 
-type HookAttachment struct {
-	Title     string `json:"title"`
-	TitleLink string `json:"title_link"`
-	Text      string `json:"text"`
-	ImageURL  string `json:"image_url"`
-	Color     string `json:"color"`
+// HookError is returned when the server does not accept the webhook message.
+type HookError struct {
+	StatusCode int
+	Message    string
 }
 
-type HookResponse struct {
-	Success bool `json:"success"`
+func (e *HookError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("webhook failed with status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("webhook failed with status %d: %s", e.StatusCode, e.Message)
 }
 
+// Hooks posts a message to the incoming webhook with the given token.
 func (c *Client) Hooks(msg *HookMessage, token string) (*HookResponse, error) {
-	opt, _ := json.Marshal(msg)
+	return c.HooksURL(msg, fmt.Sprintf("%s/hooks/%s", c.baseURL, token))
+}
+
+// HooksURL posts a message to the full URL of an incoming webhook.
+func (c *Client) HooksURL(msg *HookMessage, url string) (*HookResponse, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
 
-	url := fmt.Sprintf("%s/hooks/%s", c.baseURL, token)
+	opt, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := http.NewRequest("POST",
 		url,
 		bytes.NewBuffer(opt))
 
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
+	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		log.Println("Request error")
 		return nil, err
 	}
 
-	res, err := c.HTTPClient.Do(req)
 	defer res.Body.Close()
 
+	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 
 	resp := HookResponse{}
 
-	if err = json.NewDecoder(res.Body).Decode(&resp); err != nil {
+	if err = json.Unmarshal(body, &resp); err != nil {
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, &HookError{StatusCode: res.StatusCode, Message: string(body)}
+		}
 		return nil, err
 	}
 
+	if !resp.Success || res.StatusCode < 200 || res.StatusCode > 299 {
+		return &resp, &HookError{StatusCode: res.StatusCode, Message: resp.Error}
+	}
+
 	return &resp, nil
 }
@@ -1,6 +1,8 @@
 package gorocket
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -32,3 +34,105 @@ func TestHooks(t *testing.T) {
 
 	require.True(t, resp.Success)
 }
+
+func TestHooksURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/hooks/YtHSdMPDsRTbbEmCf/fQcpCPmvFBx5xQWPNw", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "Alertmanager", body["alias"])
+		require.Equal(t, ":fire:", body["emoji"])
+		require.Equal(t, "#incidents", body["channel"])
+
+		attachment := body["attachments"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "Prometheus", attachment["author_name"])
+		require.Equal(t, "Severity", attachment["fields"].([]interface{})[0].(map[string]interface{})["title"])
+		require.NotContains(t, attachment, "ts")
+
+		_, err := w.Write([]byte(`{"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	msg := HookMessage{
+		Alias:   "Alertmanager",
+		Emoji:   ":fire:",
+		Channel: "#incidents",
+		Text:    "Disk almost full",
+		Attachments: []HookAttachment{
+			{
+				AuthorName: "Prometheus",
+				Fields: []AttachField{
+					{Short: true, Title: "Severity", Value: "critical"},
+				},
+			},
+		},
+	}
+
+	resp, err := client.HooksURL(&msg, server.URL+"/hooks/YtHSdMPDsRTbbEmCf/fQcpCPmvFBx5xQWPNw")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestHooksActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Attachments []struct {
+				ButtonAlignment string                   `json:"button_alignment"`
+				Actions         []map[string]interface{} `json:"actions"`
+			} `json:"attachments"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "horizontal", body.Attachments[0].ButtonAlignment)
+		require.Equal(t, map[string]interface{}{"type": "button", "text": "Open runbook", "url": "https://wiki.example.com/runbook"}, body.Attachments[0].Actions[0])
+		require.Equal(t, map[string]interface{}{"type": "button", "text": "Acknowledge", "msg": "ack 42", "msg_in_chat_window": true}, body.Attachments[0].Actions[1])
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	ack := NewMessageAction("Acknowledge", "ack 42")
+	msg := HookMessage{
+		Text: "Disk full",
+		Attachments: []HookAttachment{{
+			ButtonAlignment: ButtonAlignmentHorizontal,
+			Actions:         []AttachmentAction{NewURLAction("Open runbook", "https://wiki.example.com/runbook"), ack},
+		}},
+	}
+
+	_, err := client.Hooks(&msg, "token")
+	require.NoError(t, err)
+
+	msg.Attachments[0].Actions[0].Type = ""
+	_, err = client.Hooks(&msg, "token")
+	require.Error(t, err)
+}
+
+func TestHooksError(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		Code:         http.StatusBadRequest,
+		ResponseBody: `{"success":false,"error":"Invalid integration id or token provided."}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.Hooks(&HookMessage{Text: "Hello"}, "wrong")
+
+	hookErr, ok := err.(*HookError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, hookErr.StatusCode)
+	require.Equal(t, "Invalid integration id or token provided.", hookErr.Message)
+	require.False(t, resp.Success)
+}
+
+func TestHooksConnectionError(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{}))
+	server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	_, err := client.Hooks(&HookMessage{Text: "Hello"}, "token")
+	require.Error(t, err)
+}
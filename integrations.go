@@ -0,0 +1,312 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	IntegrationTypeIncoming = "webhook-incoming"
+	IntegrationTypeOutgoing = "webhook-outgoing"
+)
+
+// Events of outgoing integrations.
+const (
+	IntegrationEventSendMessage  = "sendMessage"
+	IntegrationEventFileUploaded = "fileUploaded"
+	IntegrationEventRoomArchived = "roomArchived"
+	IntegrationEventRoomCreated  = "roomCreated"
+	IntegrationEventRoomJoined   = "roomJoined"
+	IntegrationEventRoomLeft     = "roomLeft"
+	IntegrationEventUserCreated  = "userCreated"
+)
+
+type Integration struct {
+	ID                  string    `json:"_id"`
+	Type                string    `json:"type"`
+	Name                string    `json:"name"`
+	Enabled             bool      `json:"enabled"`
+	Username            string    `json:"username"`
+	Channel             []string  `json:"channel"`
+	Alias               string    `json:"alias,omitempty"`
+	Avatar              string    `json:"avatar,omitempty"`
+	Emoji               string    `json:"emoji,omitempty"`
+	ScriptEnabled       bool      `json:"scriptEnabled"`
+	Script              string    `json:"script,omitempty"`
+	Token               string    `json:"token,omitempty"`
+	UserID              string    `json:"userId"`
+	Event               string    `json:"event,omitempty"`
+	URLs                []string  `json:"urls,omitempty"`
+	TriggerWords        []string  `json:"triggerWords,omitempty"`
+	TriggerWordAnywhere bool      `json:"triggerWordAnywhere,omitempty"`
+	Impersonate         bool      `json:"impersonateUser,omitempty"`
+	RetryFailedCalls    bool      `json:"retryFailedCalls,omitempty"`
+	RetryCount          int       `json:"retryCount,omitempty"`
+	RetryDelay          string    `json:"retryDelay,omitempty"`
+	RunOnEdits          bool      `json:"runOnEdits,omitempty"`
+	TargetRoom          string    `json:"targetRoom,omitempty"`
+	CreatedAt           time.Time `json:"_createdAt"`
+	CreatedBy           UChat     `json:"_createdBy"`
+	UpdatedAt           time.Time `json:"_updatedAt"`
+}
+
+type IncomingIntegrationRequest struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Username is the user the messages are posted as
+	Username string `json:"username"`
+	// Channel is a comma separated list of #channels and @users
+	Channel       string `json:"channel"`
+	ScriptEnabled bool   `json:"scriptEnabled"`
+	Script        string `json:"script,omitempty"`
+	Alias         string `json:"alias,omitempty"`
+	Avatar        string `json:"avatar,omitempty"`
+	Emoji         string `json:"emoji,omitempty"`
+}
+
+type OutgoingIntegrationRequest struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Event is one of the IntegrationEvent constants
+	Event    string `json:"event"`
+	Username string `json:"username"`
+	// Channel is a comma separated list of #channels and @users, or all_public_channels,
+	// all_private_groups and all_direct_messages
+	Channel             string   `json:"channel,omitempty"`
+	URLs                []string `json:"urls"`
+	TriggerWords        []string `json:"triggerWords,omitempty"`
+	TriggerWordAnywhere bool     `json:"triggerWordAnywhere,omitempty"`
+	Token               string   `json:"token,omitempty"`
+	ScriptEnabled       bool     `json:"scriptEnabled"`
+	Script              string   `json:"script,omitempty"`
+	Alias               string   `json:"alias,omitempty"`
+	Avatar              string   `json:"avatar,omitempty"`
+	Emoji               string   `json:"emoji,omitempty"`
+	Impersonate         bool     `json:"impersonateUser,omitempty"`
+	RetryFailedCalls    bool     `json:"retryFailedCalls,omitempty"`
+	RetryCount          int      `json:"retryCount,omitempty"`
+	RetryDelay          string   `json:"retryDelay,omitempty"`
+	RunOnEdits          bool     `json:"runOnEdits,omitempty"`
+	TargetRoom          string   `json:"targetRoom,omitempty"`
+}
+
+type IntegrationResponse struct {
+	Integration Integration `json:"integration"`
+	Success     bool        `json:"success"`
+}
+
+type IntegrationsListResponse struct {
+	Integrations []Integration `json:"integrations"`
+	Offset       int           `json:"offset"`
+	Count        int           `json:"count"`
+	Items        int           `json:"items"`
+	Total        int           `json:"total"`
+	Success      bool          `json:"success"`
+}
+
+type SimpleIntegrationRequest struct {
+	Type          string `json:"type"`
+	IntegrationId string `json:"integrationId"`
+}
+
+type IntegrationHistoryResponse struct {
+	History []IntegrationHistory `json:"history"`
+	Offset  int                  `json:"offset"`
+	Count   int                  `json:"count"`
+	Items   int                  `json:"items"`
+	Total   int                  `json:"total"`
+	Success bool                 `json:"success"`
+}
+
+type IntegrationHistory struct {
+	ID          string `json:"_id"`
+	Type        string `json:"type"`
+	Step        string `json:"step"`
+	Integration struct {
+		ID string `json:"_id"`
+	} `json:"integration"`
+	Event            string          `json:"event"`
+	TriggerWord      string          `json:"triggerWord,omitempty"`
+	RanPrepareScript bool            `json:"ranPrepareScript"`
+	Finished         bool            `json:"finished"`
+	URL              string          `json:"url,omitempty"`
+	Data             json.RawMessage `json:"data,omitempty"`
+	HTTPCallData     json.RawMessage `json:"httpCallData,omitempty"`
+	HTTPError        json.RawMessage `json:"httpError,omitempty"`
+	HTTPResult       string          `json:"httpResult,omitempty"`
+	Error            bool            `json:"error"`
+	ErrorStack       string          `json:"errorStack,omitempty"`
+	CreatedAt        time.Time       `json:"_createdAt"`
+	UpdatedAt        time.Time       `json:"_updatedAt"`
+}
+
+// IntegrationsCreateIncoming creates an incoming webhook.
+func (c *Client) IntegrationsCreateIncoming(param *IncomingIntegrationRequest) (*IntegrationResponse, error) {
+	return c.createIntegration(struct {
+		Type string `json:"type"`
+		*IncomingIntegrationRequest
+	}{IntegrationTypeIncoming, param})
+}
+
+// IntegrationsCreateOutgoing creates an outgoing webhook.
+func (c *Client) IntegrationsCreateOutgoing(param *OutgoingIntegrationRequest) (*IntegrationResponse, error) {
+	return c.createIntegration(struct {
+		Type string `json:"type"`
+		*OutgoingIntegrationRequest
+	}{IntegrationTypeOutgoing, param})
+}
+
+func (c *Client) createIntegration(param interface{}) (*IntegrationResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/integrations.create", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IntegrationsList lists the integrations on the server.
+func (c *Client) IntegrationsList() (*IntegrationsListResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/integrations.list", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationsListResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IntegrationsGet gets an integration by id.
+func (c *Client) IntegrationsGet(integrationId string) (*IntegrationResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/integrations.get", c.baseURL, c.apiVersion),
+		nil)
+
+	if integrationId == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	url := req.URL.Query()
+	url.Add("integrationId", integrationId)
+	req.URL.RawQuery = url.Encode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IntegrationsUpdateIncoming updates an incoming webhook.
+func (c *Client) IntegrationsUpdateIncoming(integrationId string, param *IncomingIntegrationRequest) (*IntegrationResponse, error) {
+	return c.updateIntegration(struct {
+		Type          string `json:"type"`
+		IntegrationId string `json:"integrationId"`
+		*IncomingIntegrationRequest
+	}{IntegrationTypeIncoming, integrationId, param})
+}
+
+// IntegrationsUpdateOutgoing updates an outgoing webhook.
+func (c *Client) IntegrationsUpdateOutgoing(integrationId string, param *OutgoingIntegrationRequest) (*IntegrationResponse, error) {
+	return c.updateIntegration(struct {
+		Type          string `json:"type"`
+		IntegrationId string `json:"integrationId"`
+		*OutgoingIntegrationRequest
+	}{IntegrationTypeOutgoing, integrationId, param})
+}
+
+func (c *Client) updateIntegration(param interface{}) (*IntegrationResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("PUT",
+		fmt.Sprintf("%s/%s/integrations.update", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IntegrationsRemove removes an integration.
+func (c *Client) IntegrationsRemove(param *SimpleIntegrationRequest) (*IntegrationResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/integrations.remove", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// IntegrationsHistory lists the history of an outgoing integration.
+func (c *Client) IntegrationsHistory(integrationId string) (*IntegrationHistoryResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/integrations.history", c.baseURL, c.apiVersion),
+		nil)
+
+	if integrationId == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	url := req.URL.Query()
+	url.Add("id", integrationId)
+	req.URL.RawQuery = url.Encode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationHistoryResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,157 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const incomingIntegration = `{"_id":"YtHSdMPDsRTbbEmCf","type":"webhook-incoming","name":"Alerts","enabled":true,"username":"rocket.cat","channel":["#alerts"],"alias":"Alertmanager","emoji":":fire:","scriptEnabled":false,"token":"fQcpCPmvFBx5xQWPNw","userId":"rocket.cat","_createdAt":"2023-10-10T23:40:46.093Z","_createdBy":{"_id":"aobEdbYhXfu5hkeqG","username":"admin"},"_updatedAt":"2023-10-10T23:40:46.093Z"}`
+
+func TestIntegrationsCreateIncoming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, IntegrationTypeIncoming, body["type"])
+		require.Equal(t, "#alerts", body["channel"])
+		_, err := w.Write([]byte(`{"integration":` + incomingIntegration + `,"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsCreateIncoming(&IncomingIntegrationRequest{
+		Name:     "Alerts",
+		Enabled:  true,
+		Username: "rocket.cat",
+		Channel:  "#alerts",
+		Alias:    "Alertmanager",
+		Emoji:    ":fire:",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "YtHSdMPDsRTbbEmCf", resp.Integration.ID)
+	require.Equal(t, []string{"#alerts"}, resp.Integration.Channel)
+	require.Equal(t, "fQcpCPmvFBx5xQWPNw", resp.Integration.Token)
+	require.Equal(t, "admin", resp.Integration.CreatedBy.Username)
+	require.True(t, resp.Success)
+}
+
+func TestIntegrationsCreateOutgoing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, IntegrationTypeOutgoing, body["type"])
+		require.Equal(t, IntegrationEventSendMessage, body["event"])
+		_, err := w.Write([]byte(`{"integration":{"_id":"WMQDChpnYTRmFre9h","type":"webhook-outgoing","name":"Deploy","enabled":true,"username":"rocket.cat","channel":["#deploys"],"event":"sendMessage","urls":["https://ci.example.com/hook"],"triggerWords":["!deploy"],"scriptEnabled":false,"userId":"rocket.cat"},"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsCreateOutgoing(&OutgoingIntegrationRequest{
+		Name:         "Deploy",
+		Enabled:      true,
+		Event:        IntegrationEventSendMessage,
+		Username:     "rocket.cat",
+		Channel:      "#deploys",
+		URLs:         []string{"https://ci.example.com/hook"},
+		TriggerWords: []string{"!deploy"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, IntegrationTypeOutgoing, resp.Integration.Type)
+	require.Equal(t, []string{"!deploy"}, resp.Integration.TriggerWords)
+	require.Equal(t, []string{"https://ci.example.com/hook"}, resp.Integration.URLs)
+	require.True(t, resp.Success)
+}
+
+func TestIntegrationsList(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"integrations":[` + incomingIntegration + `],"offset":0,"items":1,"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsList()
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(resp.Integrations))
+	require.Equal(t, "Alerts", resp.Integrations[0].Name)
+	require.Equal(t, 1, resp.Total)
+	require.True(t, resp.Success)
+}
+
+func TestIntegrationsGet(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"integration":` + incomingIntegration + `,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsGet("YtHSdMPDsRTbbEmCf")
+	require.NoError(t, err)
+
+	require.Equal(t, "YtHSdMPDsRTbbEmCf", resp.Integration.ID)
+	require.True(t, resp.Success)
+
+	_, err = client.IntegrationsGet("")
+	require.Error(t, err)
+}
+
+func TestIntegrationsUpdateIncoming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "YtHSdMPDsRTbbEmCf", body["integrationId"])
+		_, err := w.Write([]byte(`{"integration":` + incomingIntegration + `,"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsUpdateIncoming("YtHSdMPDsRTbbEmCf", &IncomingIntegrationRequest{
+		Name:     "Alerts",
+		Enabled:  true,
+		Username: "rocket.cat",
+		Channel:  "#alerts",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestIntegrationsRemove(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"integration":` + incomingIntegration + `,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsRemove(&SimpleIntegrationRequest{
+		Type:          IntegrationTypeIncoming,
+		IntegrationId: "YtHSdMPDsRTbbEmCf",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestIntegrationsHistory(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"history":[{"_id":"ZxNJKQvyN8AHW7sP9","type":"outgoing-webhook","step":"finished","integration":{"_id":"WMQDChpnYTRmFre9h"},"event":"sendMessage","triggerWord":"!deploy","ranPrepareScript":false,"finished":true,"url":"https://ci.example.com/hook","httpResult":"ok","error":false,"_createdAt":"2023-10-10T23:40:46.093Z","_updatedAt":"2023-10-10T23:40:47.093Z"}],"offset":0,"items":1,"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.IntegrationsHistory("WMQDChpnYTRmFre9h")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(resp.History))
+	require.Equal(t, "WMQDChpnYTRmFre9h", resp.History[0].Integration.ID)
+	require.Equal(t, "!deploy", resp.History[0].TriggerWord)
+	require.True(t, resp.History[0].Finished)
+	require.True(t, resp.Success)
+}
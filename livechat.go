@@ -0,0 +1,417 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LivechatClient talks to the Livechat API as a visitor. Visitors have no
+// user account, they are identified by their token instead.
+type LivechatClient struct {
+	client *Client
+	token  string
+}
+
+// NewLivechatClient creates a visitor client sharing the server and HTTP
+// client of client. Keep the token to resume the visitor's conversations
+// later, a new token is generated if it is empty.
+func NewLivechatClient(client *Client, token string) (*LivechatClient, error) {
+	if token == "" {
+		var err error
+		if token, err = randomID(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LivechatClient{client: client, token: token}, nil
+}
+
+// Token returns the visitor token.
+func (l *LivechatClient) Token() string {
+	return l.token
+}
+
+type LivechatVisitorRequest struct {
+	Name         string                `json:"name,omitempty"`
+	Email        string                `json:"email,omitempty"`
+	Phone        string                `json:"phone,omitempty"`
+	Username     string                `json:"username,omitempty"`
+	Department   string                `json:"department,omitempty"`
+	CustomFields []LivechatCustomField `json:"customFields,omitempty"`
+}
+
+type LivechatCustomField struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+type LivechatVisitor struct {
+	ID            string                 `json:"_id"`
+	Token         string                 `json:"token"`
+	Name          string                 `json:"name,omitempty"`
+	Username      string                 `json:"username"`
+	Department    string                 `json:"department,omitempty"`
+	VisitorEmails []LivechatVisitorEmail `json:"visitorEmails,omitempty"`
+	Phone         []LivechatVisitorPhone `json:"phone,omitempty"`
+	LivechatData  CustomFields           `json:"livechatData,omitempty"`
+	Ts            time.Time              `json:"ts"`
+	UpdatedAt     time.Time              `json:"_updatedAt"`
+}
+
+type LivechatVisitorEmail struct {
+	Address string `json:"address"`
+}
+
+type LivechatVisitorPhone struct {
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+type LivechatVisitorResponse struct {
+	Visitor LivechatVisitor `json:"visitor"`
+	Success bool            `json:"success"`
+	Error   string          `json:"error,omitempty"`
+}
+
+type LivechatRoomRequest struct {
+	// RoomID resumes an open room, a new room is created if it is empty
+	RoomID string
+	// AgentID asks for a specific agent
+	AgentID    string
+	Department string
+}
+
+type LivechatRoom struct {
+	ID           string       `json:"_id"`
+	Type         string       `json:"t"`
+	FName        string       `json:"fname,omitempty"`
+	Msgs         int          `json:"msgs"`
+	Open         bool         `json:"open"`
+	Department   string       `json:"departmentId,omitempty"`
+	ServedBy     *UChat       `json:"servedBy,omitempty"`
+	Tags         []string     `json:"tags,omitempty"`
+	LivechatData CustomFields `json:"livechatData,omitempty"`
+	Visitor      struct {
+		ID       string `json:"_id"`
+		Token    string `json:"token"`
+		Username string `json:"username"`
+	} `json:"v"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+	ClosedBy  *UChat     `json:"closedBy,omitempty"`
+	Ts        time.Time  `json:"ts"`
+	UpdatedAt time.Time  `json:"_updatedAt"`
+}
+
+type LivechatRoomResponse struct {
+	Room    LivechatRoom `json:"room"`
+	NewRoom bool         `json:"newRoom"`
+	Success bool         `json:"success"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type LivechatCloseRoomResponse struct {
+	RoomID  string `json:"rid"`
+	Comment string `json:"comment"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type LivechatMessageRequest struct {
+	RoomID string `json:"rid"`
+	Msg    string `json:"msg"`
+	// ID sets the id of the new message, optional
+	ID string `json:"_id,omitempty"`
+}
+
+type LivechatMessageResponse struct {
+	Message RoomMessage `json:"message"`
+	Success bool        `json:"success"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type LivechatHistoryRequest struct {
+	RoomID string
+	// Latest only returns messages older than Latest
+	Latest time.Time
+	// End only returns messages newer than End
+	End   time.Time
+	Limit int
+}
+
+type LivechatHistoryResponse struct {
+	Messages []RoomMessage `json:"messages"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
+}
+
+type LivechatTranscriptResponse struct {
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type LivechatConfigResponse struct {
+	Config  LivechatConfig `json:"config"`
+	Success bool           `json:"success"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type LivechatConfig struct {
+	Enabled     bool                   `json:"enabled"`
+	Online      bool                   `json:"online"`
+	Settings    map[string]interface{} `json:"settings"`
+	Theme       map[string]interface{} `json:"theme"`
+	Messages    map[string]interface{} `json:"messages"`
+	Survey      map[string]interface{} `json:"survey"`
+	Triggers    []json.RawMessage      `json:"triggers"`
+	Departments []LivechatDepartment   `json:"departments"`
+	Resources   map[string]interface{} `json:"resources"`
+	Guest       *LivechatVisitor       `json:"guest,omitempty"`
+	Room        *LivechatRoom          `json:"room,omitempty"`
+	Agent       *UChat                 `json:"agent,omitempty"`
+}
+
+type LivechatDepartment struct {
+	ID                 string    `json:"_id"`
+	Enabled            bool      `json:"enabled"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description,omitempty"`
+	Email              string    `json:"email,omitempty"`
+	ShowOnRegistration bool      `json:"showOnRegistration"`
+	ShowOnOfflineForm  bool      `json:"showOnOfflineForm"`
+	NumAgents          int       `json:"numAgents"`
+	UpdatedAt          time.Time `json:"_updatedAt"`
+}
+
+// RegisterVisitor registers the visitor, or updates it if it already exists.
+func (l *LivechatClient) RegisterVisitor(param *LivechatVisitorRequest) (*LivechatVisitorResponse, error) {
+	opt, _ := json.Marshal(map[string]interface{}{
+		"visitor": struct {
+			Token string `json:"token"`
+			*LivechatVisitorRequest
+		}{l.token, param},
+	})
+
+	req, err := http.NewRequest("POST",
+		l.url("livechat/visitor"),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatVisitorResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetVisitor gets the visitor.
+func (l *LivechatClient) GetVisitor() (*LivechatVisitorResponse, error) {
+	req, err := http.NewRequest("GET",
+		l.url("livechat/visitor/"+url.PathEscape(l.token)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatVisitorResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Room opens a room for the visitor, or returns the open room with the given id.
+func (l *LivechatClient) Room(param *LivechatRoomRequest) (*LivechatRoomResponse, error) {
+	req, err := http.NewRequest("GET",
+		l.url("livechat/room"),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	url.Add("token", l.token)
+	if param.RoomID != "" {
+		url.Add("rid", param.RoomID)
+	}
+	if param.AgentID != "" {
+		url.Add("agentId", param.AgentID)
+	}
+	if param.Department != "" {
+		url.Add("department", param.Department)
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := LivechatRoomResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CloseRoom closes a room of the visitor.
+func (l *LivechatClient) CloseRoom(roomID string) (*LivechatCloseRoomResponse, error) {
+	if roomID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{
+		"rid":   roomID,
+		"token": l.token,
+	})
+
+	req, err := http.NewRequest("POST",
+		l.url("livechat/room.close"),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatCloseRoomResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SendMessage sends a message to a room of the visitor.
+func (l *LivechatClient) SendMessage(param *LivechatMessageRequest) (*LivechatMessageResponse, error) {
+	if param.RoomID == "" || param.Msg == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(struct {
+		Token string `json:"token"`
+		*LivechatMessageRequest
+	}{l.token, param})
+
+	req, err := http.NewRequest("POST",
+		l.url("livechat/message"),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatMessageResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// MessagesHistory loads the messages of a room of the visitor, newest first.
+func (l *LivechatClient) MessagesHistory(param *LivechatHistoryRequest) (*LivechatHistoryResponse, error) {
+	if param.RoomID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		l.url("livechat/messages.history/"+url.PathEscape(param.RoomID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	url.Add("token", l.token)
+	if !param.Latest.IsZero() {
+		url.Add("ls", param.Latest.UTC().Format(time.RFC3339Nano))
+	}
+	if !param.End.IsZero() {
+		url.Add("end", param.End.UTC().Format(time.RFC3339Nano))
+	}
+	if param.Limit != 0 {
+		url.Add("limit", strconv.Itoa(param.Limit))
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := LivechatHistoryResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RequestTranscript emails the transcript of a room of the visitor.
+func (l *LivechatClient) RequestTranscript(roomID, email string) (*LivechatTranscriptResponse, error) {
+	if roomID == "" || email == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{
+		"token": l.token,
+		"rid":   roomID,
+		"email": email,
+	})
+
+	req, err := http.NewRequest("POST",
+		l.url("livechat/transcript"),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatTranscriptResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Config gets the Livechat widget configuration for the visitor.
+func (l *LivechatClient) Config(department string) (*LivechatConfigResponse, error) {
+	req, err := http.NewRequest("GET",
+		l.url("livechat/config"),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	url.Add("token", l.token)
+	if department != "" {
+		url.Add("department", department)
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := LivechatConfigResponse{}
+
+	if err := l.client.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (l *LivechatClient) url(endpoint string) string {
+	return fmt.Sprintf("%s/%s/%s", l.client.baseURL, l.client.apiVersion, endpoint)
+}
@@ -0,0 +1,175 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// livechatHandler checks that visitor requests are sent without user credentials.
+func livechatHandler(t *testing.T, check func(r *http.Request), body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("X-Auth-Token"))
+		require.Empty(t, r.Header.Get("X-User-Id"))
+		check(r)
+		w.Write([]byte(body))
+	}
+}
+
+func newTestLivechatClient(t *testing.T, server *httptest.Server) *LivechatClient {
+	client := NewTestClientWithCustomHandler(t, server)
+	client.setCredentials("user", "token")
+
+	livechat, err := NewLivechatClient(client, "visitor-token")
+	require.NoError(t, err)
+
+	return livechat
+}
+
+func TestNewLivechatClient(t *testing.T) {
+	livechat, err := NewLivechatClient(NewClient("http://localhost"), "")
+	require.NoError(t, err)
+	require.Equal(t, 24, len(livechat.Token()))
+}
+
+func TestLivechatRegisterVisitor(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/visitor", r.URL.Path)
+
+		body := struct {
+			Visitor map[string]interface{} `json:"visitor"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "visitor-token", body.Visitor["token"])
+		require.Equal(t, "Jane", body.Visitor["name"])
+		require.Equal(t, []interface{}{map[string]interface{}{"key": "plan", "value": "pro", "overwrite": true}}, body.Visitor["customFields"])
+	}, `{"visitor":{"_id":"q5L2dTqtAE4p2yfk6","username":"guest-2","token":"visitor-token","name":"Jane","visitorEmails":[{"address":"jane@example.com"}],"livechatData":{"plan":"pro"},"ts":"2018-03-01T18:02:26.825Z","_updatedAt":"2018-03-01T18:02:26.828Z"},"success":true}`))
+	defer server.Close()
+
+	livechat := newTestLivechatClient(t, server)
+	resp, err := livechat.RegisterVisitor(&LivechatVisitorRequest{
+		Name:         "Jane",
+		Email:        "jane@example.com",
+		CustomFields: []LivechatCustomField{{Key: "plan", Value: "pro", Overwrite: true}},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, "guest-2", resp.Visitor.Username)
+	require.Equal(t, "jane@example.com", resp.Visitor.VisitorEmails[0].Address)
+	require.Equal(t, "pro", resp.Visitor.LivechatData["plan"])
+}
+
+func TestLivechatGetVisitor(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/visitor/visitor-token", r.URL.Path)
+	}, `{"visitor":{"_id":"q5L2dTqtAE4p2yfk6","username":"guest-2","token":"visitor-token"},"success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).GetVisitor()
+	require.NoError(t, err)
+	require.Equal(t, "q5L2dTqtAE4p2yfk6", resp.Visitor.ID)
+}
+
+func TestLivechatRoom(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/room", r.URL.Path)
+		require.Equal(t, "visitor-token", r.URL.Query().Get("token"))
+		require.Equal(t, "support", r.URL.Query().Get("department"))
+	}, `{"room":{"_id":"zRAeTszXor8CCPbm3","t":"l","msgs":0,"open":true,"departmentId":"support","v":{"_id":"q5L2dTqtAE4p2yfk6","token":"visitor-token","username":"guest-2"},"servedBy":{"_id":"agent1","username":"agent"},"ts":"2018-03-01T18:02:26.825Z"},"newRoom":true,"success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).Room(&LivechatRoomRequest{Department: "support"})
+	require.NoError(t, err)
+	require.True(t, resp.NewRoom)
+	require.Equal(t, "zRAeTszXor8CCPbm3", resp.Room.ID)
+	require.Equal(t, "agent", resp.Room.ServedBy.Username)
+	require.Equal(t, "guest-2", resp.Room.Visitor.Username)
+}
+
+func TestLivechatCloseRoom(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/room.close", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]string{"rid": "zRAeTszXor8CCPbm3", "token": "visitor-token"}, body)
+	}, `{"rid":"zRAeTszXor8CCPbm3","comment":"Closed by visitor","success":true}`))
+	defer server.Close()
+
+	livechat := newTestLivechatClient(t, server)
+	resp, err := livechat.CloseRoom("zRAeTszXor8CCPbm3")
+	require.NoError(t, err)
+	require.Equal(t, "Closed by visitor", resp.Comment)
+
+	_, err = livechat.CloseRoom("")
+	require.Error(t, err)
+}
+
+func TestLivechatSendMessage(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/message", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]string{"rid": "zRAeTszXor8CCPbm3", "msg": "Hello", "token": "visitor-token"}, body)
+	}, `{"message":{"_id":"ZKWP8LfGnRHQ3ozWa","rid":"zRAeTszXor8CCPbm3","msg":"Hello","token":"visitor-token","u":{"_id":"q5L2dTqtAE4p2yfk6","username":"guest-2","name":"Jane"},"ts":"2018-03-01T18:02:26.825Z"},"success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).SendMessage(&LivechatMessageRequest{RoomID: "zRAeTszXor8CCPbm3", Msg: "Hello"})
+	require.NoError(t, err)
+	require.Equal(t, "ZKWP8LfGnRHQ3ozWa", resp.Message.ID)
+	require.Equal(t, "Jane", resp.Message.U.Name)
+}
+
+func TestLivechatMessagesHistory(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/messages.history/zRAeTszXor8CCPbm3", r.URL.Path)
+		require.Equal(t, "visitor-token", r.URL.Query().Get("token"))
+		require.Equal(t, "2018-03-01T18:00:00Z", r.URL.Query().Get("ls"))
+		require.Equal(t, "20", r.URL.Query().Get("limit"))
+	}, `{"messages":[{"_id":"ZKWP8LfGnRHQ3ozWa","rid":"zRAeTszXor8CCPbm3","msg":"Hello","u":{"_id":"q5L2dTqtAE4p2yfk6","username":"guest-2"},"ts":"2018-03-01T17:02:26.825Z"}],"success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).MessagesHistory(&LivechatHistoryRequest{
+		RoomID: "zRAeTszXor8CCPbm3",
+		Latest: time.Date(2018, 3, 1, 18, 0, 0, 0, time.UTC),
+		Limit:  20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Messages))
+	require.Equal(t, "Hello", resp.Messages[0].Msg)
+}
+
+func TestLivechatRequestTranscript(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/transcript", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "jane@example.com", body["email"])
+	}, `{"message":"Livechat transcript sent","success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).RequestTranscript("zRAeTszXor8CCPbm3", "jane@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "Livechat transcript sent", resp.Message)
+}
+
+func TestLivechatConfig(t *testing.T) {
+	server := httptest.NewServer(livechatHandler(t, func(r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/config", r.URL.Path)
+		require.Equal(t, "visitor-token", r.URL.Query().Get("token"))
+	}, `{"config":{"enabled":true,"online":true,"settings":{"registrationForm":true},"theme":{"title":"Support"},"messages":{},"survey":{},"triggers":[],"departments":[{"_id":"support","name":"Support","showOnRegistration":true}],"resources":{}},"success":true}`))
+	defer server.Close()
+
+	resp, err := newTestLivechatClient(t, server).Config("")
+	require.NoError(t, err)
+	require.True(t, resp.Config.Enabled)
+	require.True(t, resp.Config.Online)
+	require.Equal(t, "Support", resp.Config.Theme["title"])
+	require.Equal(t, "Support", resp.Config.Departments[0].Name)
+}
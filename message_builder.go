@@ -0,0 +1,188 @@
+package gorocket
+
+import (
+	"strings"
+)
+
+// zeroWidthSpace breaks mentions without changing how the text looks.
+const zeroWidthSpace = "\u200b"
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"~", `\~`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	">", `\>`,
+	"@", "@"+zeroWidthSpace,
+	"#", "#"+zeroWidthSpace,
+)
+
+// EscapeMarkdown escapes user-provided text, so it is shown as is: formatting
+// characters are escaped and @mentions and #channels do not notify anyone.
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// MessageBuilder builds a *Message for PostMessage. Text added with Text,
+// Bold, Italic and Strike is escaped, use Markdown to add raw markdown.
+type MessageBuilder struct {
+	msg  Message
+	text strings.Builder
+}
+
+// NewMessage creates a new message builder.
+func NewMessage() *MessageBuilder {
+	return &MessageBuilder{}
+}
+
+// Room sets the room id the message is posted to.
+func (b *MessageBuilder) Room(roomID string) *MessageBuilder {
+	b.msg.RoomID = roomID
+	return b
+}
+
+// Channel sets the #channel or @user the message is posted to.
+func (b *MessageBuilder) Channel(channel string) *MessageBuilder {
+	b.msg.Channel = channel
+	return b
+}
+
+// Alias sets the name shown instead of the username.
+func (b *MessageBuilder) Alias(alias string) *MessageBuilder {
+	b.msg.Alias = alias
+	return b
+}
+
+// Emoji sets the emoji shown as the avatar.
+func (b *MessageBuilder) Emoji(emoji string) *MessageBuilder {
+	b.msg.Emoji = emoji
+	return b
+}
+
+// Avatar sets the URL of the avatar image.
+func (b *MessageBuilder) Avatar(url string) *MessageBuilder {
+	b.msg.Avatar = url
+	return b
+}
+
+// Text adds escaped text.
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.text.WriteString(EscapeMarkdown(text))
+	return b
+}
+
+// Markdown adds text as is.
+func (b *MessageBuilder) Markdown(markdown string) *MessageBuilder {
+	b.text.WriteString(markdown)
+	return b
+}
+
+// Bold adds escaped bold text.
+func (b *MessageBuilder) Bold(text string) *MessageBuilder {
+	return b.wrap("*", text)
+}
+
+// Italic adds escaped italic text.
+func (b *MessageBuilder) Italic(text string) *MessageBuilder {
+	return b.wrap("_", text)
+}
+
+// Strike adds escaped strikethrough text.
+func (b *MessageBuilder) Strike(text string) *MessageBuilder {
+	return b.wrap("~", text)
+}
+
+// Code adds inline code. Text with backticks or line breaks is added as a code block.
+func (b *MessageBuilder) Code(code string) *MessageBuilder {
+	if strings.ContainsAny(code, "`\n") {
+		return b.CodeBlock("", code)
+	}
+
+	b.text.WriteString("`" + code + "`")
+	return b
+}
+
+// CodeBlock adds a code block with optional syntax highlighting.
+func (b *MessageBuilder) CodeBlock(language, code string) *MessageBuilder {
+	code = strings.Replace(code, "```", "`"+zeroWidthSpace+"``", -1)
+
+	b.newlineIfNeeded()
+	b.text.WriteString("```" + language + "\n" + code + "\n```\n")
+	return b
+}
+
+// Quote adds an escaped quote.
+func (b *MessageBuilder) Quote(text string) *MessageBuilder {
+	b.newlineIfNeeded()
+	for _, line := range strings.Split(text, "\n") {
+		b.text.WriteString("> " + EscapeMarkdown(line) + "\n")
+	}
+	return b
+}
+
+// Mention mentions a user, with or without the leading @.
+func (b *MessageBuilder) Mention(username string) *MessageBuilder {
+	b.text.WriteString("@" + strings.TrimPrefix(username, "@"))
+	return b
+}
+
+// ChannelMention links a channel, with or without the leading #.
+func (b *MessageBuilder) ChannelMention(channel string) *MessageBuilder {
+	b.text.WriteString("#" + strings.TrimPrefix(channel, "#"))
+	return b
+}
+
+// Link adds a link. The URL is shown if title is empty.
+func (b *MessageBuilder) Link(url, title string) *MessageBuilder {
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+	if title == "" {
+		b.text.WriteString(url)
+		return b
+	}
+
+	b.text.WriteString("[" + EscapeMarkdown(title) + "](" + url + ")")
+	return b
+}
+
+// Line adds a line break.
+func (b *MessageBuilder) Line() *MessageBuilder {
+	b.text.WriteString("\n")
+	return b
+}
+
+// Attachment adds an attachment.
+func (b *MessageBuilder) Attachment(attachment Attachment) *MessageBuilder {
+	b.msg.Attachments = append(b.msg.Attachments, attachment)
+	return b
+}
+
+// Blocks adds UI Kit blocks.
+func (b *MessageBuilder) Blocks(blocks ...Block) *MessageBuilder {
+	b.msg.Blocks = append(b.msg.Blocks, blocks...)
+	return b
+}
+
+// Build returns the message.
+func (b *MessageBuilder) Build() *Message {
+	msg := b.msg
+	msg.Text = b.text.String()
+	msg.Attachments = append([]Attachment(nil), b.msg.Attachments...)
+	msg.Blocks = append(Blocks(nil), b.msg.Blocks...)
+
+	return &msg
+}
+
+func (b *MessageBuilder) wrap(marker, text string) *MessageBuilder {
+	b.text.WriteString(marker + EscapeMarkdown(text) + marker)
+	return b
+}
+
+func (b *MessageBuilder) newlineIfNeeded() {
+	s := b.text.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		b.text.WriteString("\n")
+	}
+}
@@ -0,0 +1,57 @@
+package gorocket
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	require.Equal(t, `\*bold\* \_it\_ \~st\~ \`+"`code\\`"+` \[x\](y) \> q \\`, EscapeMarkdown("*bold* _it_ ~st~ `code` [x](y) > q \\"))
+	require.Equal(t, "hi @"+zeroWidthSpace+"all and #"+zeroWidthSpace+"general", EscapeMarkdown("hi @all and #general"))
+}
+
+func TestMessageBuilder(t *testing.T) {
+	msg := NewMessage().
+		Room("GENERAL").
+		Alias("Deploy bot").
+		Text("Deploy of ").
+		Bold("api_v2").
+		Text(" by ").
+		Mention("@john").
+		Text(" finished: ").
+		Code("make deploy").
+		Line().
+		Link("https://ci.example.com/builds/1 (latest)", "Build *1*").
+		Attachment(Attachment{Title: "Changelog"}).
+		Build()
+
+	require.Equal(t, "GENERAL", msg.RoomID)
+	require.Equal(t, "Deploy bot", msg.Alias)
+	require.Equal(t, "Deploy of *api\\_v2* by @john finished: `make deploy`\n[Build \\*1\\*](https://ci.example.com/builds/1%20%28latest%29)", msg.Text)
+	require.Equal(t, 1, len(msg.Attachments))
+	require.Equal(t, "Changelog", msg.Attachments[0].Title)
+}
+
+func TestMessageBuilderCodeBlock(t *testing.T) {
+	msg := NewMessage().
+		Text("Output:").
+		Code("line 1\nline ```2```").
+		Quote("@here said\nsomething").
+		Build()
+
+	require.Equal(t, "Output:\n```\nline 1\nline `"+zeroWidthSpace+"``2`"+zeroWidthSpace+"``\n```\n> @"+zeroWidthSpace+"here said\n> something\n", msg.Text)
+}
+
+func TestMessageBuilderPostMessage(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"ts":1481748965123,"channel":"general","message":{"alias":"","msg":"*Hi* @john","parseUrls":true,"groupable":false,"ts":"2016-12-14T20:56:05.117Z","u":{"_id":"y65tAmHs93aDChMWu","username":"graywolf336"},"rid":"GENERAL","_updatedAt":"2016-12-14T20:56:05.119Z","_id":"jC9chsFddTvsbFQG7"},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.PostMessage(NewMessage().Room("GENERAL").Bold("Hi").Text(" ").Mention("john").Build())
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
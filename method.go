@@ -0,0 +1,162 @@
+package gorocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// MethodError is returned when a server method fails.
+type MethodError struct {
+	Code      string `json:"error"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	ErrorType string `json:"errorType"`
+}
+
+func (e *MethodError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Reason != "" {
+		return fmt.Sprintf("%s [%s]", e.Reason, e.Code)
+	}
+
+	return e.Code
+}
+
+// UnmarshalJSON accepts numeric error codes, such as 403 for a failed login.
+func (e *MethodError) UnmarshalJSON(b []byte) error {
+	type methodError MethodError
+
+	v := struct {
+		methodError
+		Code interface{} `json:"error"`
+	}{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*e = MethodError(v.methodError)
+	switch code := v.Code.(type) {
+	case string:
+		e.Code = code
+	case float64:
+		e.Code = strconv.FormatFloat(code, 'f', -1, 64)
+	}
+
+	return nil
+}
+
+type methodCall struct {
+	Msg    string        `json:"msg"`
+	ID     string        `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type methodResult struct {
+	Msg    string          `json:"msg"`
+	ID     string          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *MethodError    `json:"error"`
+}
+
+type methodCallResponse struct {
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// MethodResult is the JSON result of a server method.
+type MethodResult json.RawMessage
+
+// Decode decodes the result into the value pointed to by v.
+func (r MethodResult) Decode(v interface{}) error {
+	if len(r) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r, v)
+}
+
+// MethodCaller calls server methods, over the REST bridge with a *Client or
+// over the realtime connection with a *RealtimeClient.
+type MethodCaller interface {
+	CallMethod(ctx context.Context, method string, params ...interface{}) (MethodResult, error)
+}
+
+// CallMethod calls a server method through the REST bridge. A failed method
+// returns a *MethodError.
+func (c *Client) CallMethod(ctx context.Context, method string, params ...interface{}) (MethodResult, error) {
+	return c.postMethod(ctx, "method.call", method, params, c.sendRequest)
+}
+
+// CallMethodAnon calls a server method that needs no login through the REST bridge.
+func (c *Client) CallMethodAnon(ctx context.Context, method string, params ...interface{}) (MethodResult, error) {
+	return c.postMethod(ctx, "method.callAnon", method, params, c.sendAnonymous)
+}
+
+func (c *Client) postMethod(ctx context.Context, endpoint, method string, params []interface{},
+	send func(req *http.Request, v interface{}) error) (MethodResult, error) {
+	if method == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	id, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	message, err := json.Marshal(methodCall{Msg: "method", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	opt, _ := json.Marshal(map[string]string{"message": string(message)})
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		fmt.Sprintf("%s/%s/%s/%s", c.baseURL, c.apiVersion, endpoint, url.PathEscape(method)),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := methodCallResponse{}
+
+	if err := send(req, &res); err != nil {
+		return nil, err
+	}
+	if !res.Success {
+		return nil, fmt.Errorf("method %s failed: %s", method, res.Error)
+	}
+
+	result := methodResult{}
+	if err := json.Unmarshal([]byte(res.Message), &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return MethodResult(result.Result), nil
+}
+
+// decode returns the method error, or decodes the result into result if not nil.
+func (r methodResult) decode(result interface{}) error {
+	if r.Error != nil {
+		return r.Error
+	}
+	if result == nil || len(r.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Result, result)
+}
@@ -0,0 +1,93 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ MethodCaller = (*Client)(nil)
+	_ MethodCaller = (*RealtimeClient)(nil)
+)
+
+func TestCallMethod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/method.call/getUsersOfRoom", r.URL.Path)
+		require.Equal(t, "token", r.Header.Get("X-Auth-Token"))
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		call := methodCall{}
+		require.NoError(t, json.Unmarshal([]byte(body["message"]), &call))
+		require.Equal(t, "method", call.Msg)
+		require.Equal(t, "getUsersOfRoom", call.Method)
+		require.Equal(t, []interface{}{"GENERAL", true}, call.Params)
+
+		message, _ := json.Marshal(map[string]interface{}{"msg": "result", "id": call.ID, "result": map[string]interface{}{"total": 2}})
+		json.NewEncoder(w).Encode(map[string]interface{}{"message": string(message), "success": true})
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("token"))
+	res, err := client.CallMethod(context.Background(), "getUsersOfRoom", "GENERAL", true)
+	require.NoError(t, err)
+
+	result := struct {
+		Total int `json:"total"`
+	}{}
+	require.NoError(t, res.Decode(&result))
+	require.Equal(t, 2, result.Total)
+}
+
+func TestCallMethodAnon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/method.callAnon/getSetupWizardParameters", r.URL.Path)
+		require.Empty(t, r.Header.Get("X-Auth-Token"))
+
+		w.Write([]byte(`{"message":"{\"msg\":\"result\",\"id\":\"1\",\"error\":{\"isClientSafe\":true,\"error\":\"error-not-allowed\",\"reason\":\"Not allowed\",\"message\":\"Not allowed [error-not-allowed]\",\"errorType\":\"Meteor.Error\"}}","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("token"))
+	_, err := client.CallMethodAnon(context.Background(), "getSetupWizardParameters")
+	require.EqualError(t, err, "Not allowed [error-not-allowed]")
+
+	methodErr, ok := err.(*MethodError)
+	require.True(t, ok)
+	require.Equal(t, "error-not-allowed", methodErr.Code)
+}
+
+func TestRealtimeCallMethod(t *testing.T) {
+	server := newDDPServer(t, nil, func(c *ddpConn, msg map[string]interface{}) {
+		require.Equal(t, "method", msg["msg"])
+		require.Equal(t, "getRoomRoles", msg["method"])
+		require.Equal(t, []interface{}{"GENERAL"}, msg["params"])
+
+		c.result(msg["id"].(string), []map[string]interface{}{{"rid": "GENERAL", "roles": []string{"owner"}}})
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+	defer rt.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var caller MethodCaller = rt
+	res, err := caller.CallMethod(ctx, "getRoomRoles", "GENERAL")
+	require.NoError(t, err)
+
+	roles := []struct {
+		RoomID string   `json:"rid"`
+		Roles  []string `json:"roles"`
+	}{}
+	require.NoError(t, res.Decode(&roles))
+	require.Equal(t, []string{"owner"}, roles[0].Roles)
+}
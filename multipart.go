@@ -0,0 +1,51 @@
+package gorocket
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+)
+
+// newMultipartRequest creates a POST request uploading file as fileField
+// together with the form fields. file is read into memory, so the request
+// can be sent again after a re-login. file may be nil to send only fields.
+func newMultipartRequest(url string, fields map[string]string, fileField, fileName string, file io.Reader) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := w.WriteField(k, fields[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	if file != nil {
+		part, err := w.CreateFormFile(fileField, fileName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req, nil
+}
@@ -0,0 +1,110 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// NotificationPreference selects which messages of a room notify the user.
+type NotificationPreference string
+
+const (
+	NotifyDefault  NotificationPreference = "default"
+	NotifyAll      NotificationPreference = "all"
+	NotifyMentions NotificationPreference = "mentions"
+	NotifyNothing  NotificationPreference = "nothing"
+)
+
+// NotificationFlag is an on/off notification setting.
+type NotificationFlag string
+
+const (
+	NotificationFlagOn  NotificationFlag = "1"
+	NotificationFlagOff NotificationFlag = "0"
+)
+
+// NotificationSettings are the notification preferences of the user in a
+// room. Empty fields are left unchanged.
+type NotificationSettings struct {
+	DesktopNotifications    NotificationPreference `json:"desktopNotifications,omitempty"`
+	MobilePushNotifications NotificationPreference `json:"mobilePushNotifications,omitempty"`
+	EmailNotifications      NotificationPreference `json:"emailNotifications,omitempty"`
+	// AudioNotificationValue is the id of the sound played, "none" to mute
+	AudioNotificationValue string           `json:"audioNotificationValue,omitempty"`
+	DisableNotifications   NotificationFlag `json:"disableNotifications,omitempty"`
+	MuteGroupMentions      NotificationFlag `json:"muteGroupMentions,omitempty"`
+	HideUnreadStatus       NotificationFlag `json:"hideUnreadStatus,omitempty"`
+}
+
+type RoomsSaveNotificationRequest struct {
+	RoomID        string               `json:"roomId"`
+	Notifications NotificationSettings `json:"notifications"`
+}
+
+// RoomsSaveNotification saves the notification preferences of the user in a room.
+func (c *Client) RoomsSaveNotification(param *RoomsSaveNotificationRequest) (*SimpleSuccessResponse, error) {
+	if param.RoomID == "" || param.Notifications == (NotificationSettings{}) {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/rooms.saveNotification", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ApplyNotificationSettings saves the notification preferences in every room
+// the user is subscribed to, or only in the rooms of the given RoomType. It
+// stops at the first failure and returns the ids of the rooms updated so far.
+func (c *Client) ApplyNotificationSettings(settings NotificationSettings, roomTypes ...string) ([]string, error) {
+	if settings == (NotificationSettings{}) {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	subs, err := c.SubscriptionsGet(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !subs.Success {
+		return nil, fmt.Errorf("list subscriptions: %s", subs.Error)
+	}
+
+	rooms := []string{}
+	for _, s := range subs.Update {
+		if hasRoomType(roomTypes, s.Type) {
+			rooms = append(rooms, s.RoomID)
+		}
+	}
+	sort.Strings(rooms)
+
+	updated := []string{}
+	for _, roomID := range rooms {
+		res, err := c.RoomsSaveNotification(&RoomsSaveNotificationRequest{RoomID: roomID, Notifications: settings})
+		if err == nil && !res.Success {
+			err = fmt.Errorf("%s", res.Error)
+		}
+		if err != nil {
+			return updated, fmt.Errorf("save notifications of room %s: %w", roomID, err)
+		}
+		updated = append(updated, roomID)
+	}
+
+	return updated, nil
+}
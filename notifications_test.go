@@ -0,0 +1,84 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsSaveNotification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/rooms.saveNotification", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{
+			"roomId": "GENERAL",
+			"notifications": map[string]interface{}{
+				"desktopNotifications":    "mentions",
+				"mobilePushNotifications": "nothing",
+				"muteGroupMentions":       "1",
+				"hideUnreadStatus":        "0",
+			},
+		}, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RoomsSaveNotification(&RoomsSaveNotificationRequest{
+		RoomID: "GENERAL",
+		Notifications: NotificationSettings{
+			DesktopNotifications:    NotifyMentions,
+			MobilePushNotifications: NotifyNothing,
+			MuteGroupMentions:       NotificationFlagOn,
+			HideUnreadStatus:        NotificationFlagOff,
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+
+	_, err = client.RoomsSaveNotification(&RoomsSaveNotificationRequest{RoomID: "GENERAL"})
+	require.Error(t, err)
+}
+
+func TestApplyNotificationSettings(t *testing.T) {
+	var saved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/subscriptions.get":
+			w.Write([]byte(`{"update":[{"rid":"r2","t":"p"},{"rid":"r1","t":"c"},{"rid":"d1","t":"d"}],"remove":[],"success":true}`))
+		case "/api/v1/rooms.saveNotification":
+			req := RoomsSaveNotificationRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, NotificationFlagOn, req.Notifications.DisableNotifications)
+			saved = append(saved, req.RoomID)
+
+			if req.RoomID == "r2" {
+				w.Write([]byte(`{"success":false,"error":"not allowed"}`))
+				return
+			}
+			w.Write([]byte(`{"success":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	settings := NotificationSettings{DisableNotifications: NotificationFlagOn}
+
+	updated, err := client.ApplyNotificationSettings(settings, RoomTypeChannel, RoomTypeDirect)
+	require.NoError(t, err)
+	require.Equal(t, []string{"d1", "r1"}, updated)
+
+	saved = nil
+	updated, err = client.ApplyNotificationSettings(settings)
+	require.EqualError(t, err, "save notifications of room r2: not allowed")
+	require.Equal(t, []string{"d1", "r1"}, updated)
+	require.Equal(t, []string{"d1", "r1", "r2"}, saved)
+}
@@ -0,0 +1,1011 @@
+package gorocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	LivechatUserTypeAgent   = "agent"
+	LivechatUserTypeManager = "manager"
+)
+
+type LivechatUser struct {
+	ID             string   `json:"_id"`
+	Username       string   `json:"username"`
+	Name           string   `json:"name,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	StatusLivechat string   `json:"statusLivechat,omitempty"`
+	Roles          []string `json:"roles,omitempty"`
+	Emails         []struct {
+		Address  string `json:"address"`
+		Verified bool   `json:"verified"`
+	} `json:"emails,omitempty"`
+}
+
+type LivechatUsersResponse struct {
+	Users   []LivechatUser `json:"users"`
+	Offset  int            `json:"offset"`
+	Count   int            `json:"count"`
+	Total   int            `json:"total"`
+	Success bool           `json:"success"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type LivechatUserResponse struct {
+	User    LivechatUser `json:"user"`
+	Success bool         `json:"success"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type LivechatDepartmentData struct {
+	Enabled            bool   `json:"enabled"`
+	Name               string `json:"name"`
+	Description        string `json:"description,omitempty"`
+	Email              string `json:"email"`
+	ShowOnRegistration bool   `json:"showOnRegistration"`
+	ShowOnOfflineForm  bool   `json:"showOnOfflineForm"`
+}
+
+type LivechatDepartmentAgent struct {
+	ID           string `json:"_id,omitempty"`
+	AgentID      string `json:"agentId"`
+	DepartmentID string `json:"departmentId,omitempty"`
+	Username     string `json:"username"`
+	// Count and Order control how chats are distributed between the agents
+	Count int `json:"count"`
+	Order int `json:"order"`
+}
+
+type LivechatDepartmentRequest struct {
+	Department LivechatDepartmentData    `json:"department"`
+	Agents     []LivechatDepartmentAgent `json:"agents,omitempty"`
+}
+
+type LivechatDepartmentResponse struct {
+	Department LivechatDepartment        `json:"department"`
+	Agents     []LivechatDepartmentAgent `json:"agents"`
+	Success    bool                      `json:"success"`
+	Error      string                    `json:"error,omitempty"`
+}
+
+type LivechatDepartmentsResponse struct {
+	Departments []LivechatDepartment `json:"departments"`
+	Offset      int                  `json:"offset"`
+	Count       int                  `json:"count"`
+	Total       int                  `json:"total"`
+	Success     bool                 `json:"success"`
+	Error       string               `json:"error,omitempty"`
+}
+
+type LivechatDepartmentAgentsResponse struct {
+	Agents  []LivechatDepartmentAgent `json:"agents"`
+	Offset  int                       `json:"offset"`
+	Count   int                       `json:"count"`
+	Total   int                       `json:"total"`
+	Success bool                      `json:"success"`
+	Error   string                    `json:"error,omitempty"`
+}
+
+type LivechatDepartmentAgentsUpdate struct {
+	Upsert []LivechatDepartmentAgent `json:"upsert"`
+	Remove []LivechatDepartmentAgent `json:"remove"`
+}
+
+type LivechatInquiry struct {
+	ID         string    `json:"_id"`
+	RoomID     string    `json:"rid"`
+	Name       string    `json:"name"`
+	Message    string    `json:"message"`
+	Status     string    `json:"status"`
+	Department string    `json:"department,omitempty"`
+	Token      string    `json:"token"`
+	Visitor    UChat     `json:"v"`
+	Ts         time.Time `json:"ts"`
+	UpdatedAt  time.Time `json:"_updatedAt"`
+}
+
+type LivechatInquiriesResponse struct {
+	Inquiries []LivechatInquiry `json:"inquiries"`
+	Offset    int               `json:"offset"`
+	Count     int               `json:"count"`
+	Total     int               `json:"total"`
+	Success   bool              `json:"success"`
+	Error     string            `json:"error,omitempty"`
+}
+
+type TakeInquiryRequest struct {
+	InquiryID string `json:"inquiryId"`
+	// UserID is the agent taking the inquiry, the current user if empty
+	UserID string `json:"userId,omitempty"`
+}
+
+type LivechatInquiryResponse struct {
+	Inquiry LivechatInquiry `json:"inquiry"`
+	Success bool            `json:"success"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// DateRange filters by date, a zero Start or End leaves the range open.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+type LivechatRoomsRequest struct {
+	// Open lists only open rooms if true, only closed rooms if false and all rooms if nil
+	Open         *bool
+	Agents       []string
+	DepartmentID string
+	RoomName     string
+	CreatedAt    DateRange
+	ClosedAt     DateRange
+	CustomFields map[string]string
+}
+
+type LivechatRoomsResponse struct {
+	Rooms   []LivechatRoom `json:"rooms"`
+	Offset  int            `json:"offset"`
+	Count   int            `json:"count"`
+	Total   int            `json:"total"`
+	Success bool           `json:"success"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type LivechatCustomFieldDefinition struct {
+	ID           string `json:"_id"`
+	Label        string `json:"label"`
+	Scope        string `json:"scope"`
+	Visibility   string `json:"visibility"`
+	Type         string `json:"type,omitempty"`
+	Regexp       string `json:"regexp,omitempty"`
+	Required     bool   `json:"required,omitempty"`
+	DefaultValue string `json:"defaultValue,omitempty"`
+	Options      string `json:"options,omitempty"`
+}
+
+type LivechatCustomFieldsResponse struct {
+	CustomFields []LivechatCustomFieldDefinition `json:"customFields"`
+	Offset       int                             `json:"offset"`
+	Count        int                             `json:"count"`
+	Total        int                             `json:"total"`
+	Success      bool                            `json:"success"`
+	Error        string                          `json:"error,omitempty"`
+}
+
+type LivechatTag struct {
+	ID          string    `json:"_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Departments []string  `json:"departments"`
+	UpdatedAt   time.Time `json:"_updatedAt"`
+}
+
+type LivechatTagsResponse struct {
+	Tags    []LivechatTag `json:"tags"`
+	Offset  int           `json:"offset"`
+	Count   int           `json:"count"`
+	Total   int           `json:"total"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+}
+
+type LivechatTagResponse struct {
+	LivechatTag
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type LivechatTagRequest struct {
+	// ID updates an existing tag, a new tag is created if it is empty
+	ID          string   `json:"_id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Departments []string `json:"departments,omitempty"`
+}
+
+type LivechatUnit struct {
+	ID             string    `json:"_id"`
+	Name           string    `json:"name"`
+	Visibility     string    `json:"visibility"`
+	Type           string    `json:"type"`
+	NumMonitors    int       `json:"numMonitors"`
+	NumDepartments int       `json:"numDepartments"`
+	UpdatedAt      time.Time `json:"_updatedAt"`
+}
+
+type LivechatUnitsResponse struct {
+	Units   []LivechatUnit `json:"units"`
+	Offset  int            `json:"offset"`
+	Count   int            `json:"count"`
+	Total   int            `json:"total"`
+	Success bool           `json:"success"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type LivechatUnitResponse struct {
+	LivechatUnit
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type LivechatUnitData struct {
+	Name string `json:"name"`
+	// Visibility is public or private
+	Visibility string `json:"visibility"`
+}
+
+type LivechatUnitMonitor struct {
+	MonitorID string `json:"monitorId"`
+	Username  string `json:"username"`
+}
+
+type LivechatUnitDepartment struct {
+	DepartmentID string `json:"departmentId"`
+}
+
+type LivechatUnitRequest struct {
+	UnitData        LivechatUnitData         `json:"unitData"`
+	UnitMonitors    []LivechatUnitMonitor    `json:"unitMonitors"`
+	UnitDepartments []LivechatUnitDepartment `json:"unitDepartments"`
+}
+
+type LivechatUnitMonitorsResponse struct {
+	Monitors []LivechatUnitMonitor `json:"monitors"`
+	Success  bool                  `json:"success"`
+	Error    string                `json:"error,omitempty"`
+}
+
+type LivechatPriority struct {
+	ID       string `json:"_id"`
+	Name     string `json:"name,omitempty"`
+	I18n     string `json:"i18n"`
+	SortItem int    `json:"sortItem"`
+	// Dirty is true if the name was changed
+	Dirty bool `json:"dirty"`
+}
+
+type LivechatPrioritiesResponse struct {
+	Priorities []LivechatPriority `json:"priorities"`
+	Offset     int                `json:"offset"`
+	Count      int                `json:"count"`
+	Total      int                `json:"total"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type LivechatPriorityResponse struct {
+	LivechatPriority
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LivechatUsersList lists the Omnichannel agents or managers.
+func (c *Client) LivechatUsersList(userType string) (*LivechatUsersResponse, error) {
+	if !isLivechatUserType(userType) {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/users/%s", c.baseURL, c.apiVersion, userType),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUsersResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUsersAdd makes a user an Omnichannel agent or manager.
+func (c *Client) LivechatUsersAdd(userType, username string) (*LivechatUserResponse, error) {
+	if !isLivechatUserType(userType) || username == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{"username": username})
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/livechat/users/%s", c.baseURL, c.apiVersion, userType),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUserResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUsersGet gets an Omnichannel agent or manager.
+func (c *Client) LivechatUsersGet(userType, userID string) (*LivechatUserResponse, error) {
+	if !isLivechatUserType(userType) || userID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/users/%s/%s", c.baseURL, c.apiVersion, userType, url.PathEscape(userID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUserResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUsersRemove removes the agent or manager role from a user.
+func (c *Client) LivechatUsersRemove(userType, userID string) (*SimpleSuccessResponse, error) {
+	if !isLivechatUserType(userType) || userID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("DELETE",
+		fmt.Sprintf("%s/%s/livechat/users/%s/%s", c.baseURL, c.apiVersion, userType, url.PathEscape(userID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentList lists the departments.
+func (c *Client) LivechatDepartmentList() (*LivechatDepartmentsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/department", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentCreate creates a department with its agents.
+func (c *Client) LivechatDepartmentCreate(param *LivechatDepartmentRequest) (*LivechatDepartmentResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/livechat/department", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentGet gets a department with its agents.
+func (c *Client) LivechatDepartmentGet(departmentID string) (*LivechatDepartmentResponse, error) {
+	if departmentID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/department/%s", c.baseURL, c.apiVersion, url.PathEscape(departmentID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentUpdate updates a department. The agents are replaced if
+// param.Agents is set.
+func (c *Client) LivechatDepartmentUpdate(departmentID string, param *LivechatDepartmentRequest) (*LivechatDepartmentResponse, error) {
+	if departmentID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("PUT",
+		fmt.Sprintf("%s/%s/livechat/department/%s", c.baseURL, c.apiVersion, url.PathEscape(departmentID)),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentDelete deletes a department.
+func (c *Client) LivechatDepartmentDelete(departmentID string) (*SimpleSuccessResponse, error) {
+	if departmentID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("DELETE",
+		fmt.Sprintf("%s/%s/livechat/department/%s", c.baseURL, c.apiVersion, url.PathEscape(departmentID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentAgents lists the agents of a department.
+func (c *Client) LivechatDepartmentAgents(departmentID string) (*LivechatDepartmentAgentsResponse, error) {
+	if departmentID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/department/%s/agents", c.baseURL, c.apiVersion, url.PathEscape(departmentID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentAgentsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatDepartmentUpdateAgents adds, updates and removes agents of a department.
+func (c *Client) LivechatDepartmentUpdateAgents(departmentID string, param *LivechatDepartmentAgentsUpdate) (*SimpleSuccessResponse, error) {
+	if departmentID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	update := *param
+	if update.Upsert == nil {
+		update.Upsert = []LivechatDepartmentAgent{}
+	}
+	if update.Remove == nil {
+		update.Remove = []LivechatDepartmentAgent{}
+	}
+	opt, _ := json.Marshal(update)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/livechat/department/%s/agents", c.baseURL, c.apiVersion, url.PathEscape(departmentID)),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatInquiriesList lists the inquiries waiting for an agent, of all
+// departments if department is empty.
+func (c *Client) LivechatInquiriesList(department string) (*LivechatInquiriesResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/inquiries.list", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if department != "" {
+		url := req.URL.Query()
+		url.Add("department", department)
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := LivechatInquiriesResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatInquiriesTake assigns an inquiry to an agent.
+func (c *Client) LivechatInquiriesTake(param *TakeInquiryRequest) (*LivechatInquiryResponse, error) {
+	if param.InquiryID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/livechat/inquiries.take", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatInquiryResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatRooms lists the Omnichannel rooms matching the filters.
+func (c *Client) LivechatRooms(param *LivechatRoomsRequest) (*LivechatRoomsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/rooms", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	if param.Open != nil {
+		url.Add("open", strconv.FormatBool(*param.Open))
+	}
+	for _, agent := range param.Agents {
+		url.Add("agents[]", agent)
+	}
+	if param.DepartmentID != "" {
+		url.Add("departmentId", param.DepartmentID)
+	}
+	if param.RoomName != "" {
+		url.Add("roomName", param.RoomName)
+	}
+	if v := param.CreatedAt.query(); v != "" {
+		url.Add("createdAt", v)
+	}
+	if v := param.ClosedAt.query(); v != "" {
+		url.Add("closedAt", v)
+	}
+	if len(param.CustomFields) > 0 {
+		customFields, _ := json.Marshal(param.CustomFields)
+		url.Add("customFields", string(customFields))
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := LivechatRoomsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatCustomFieldsList lists the custom fields of visitors and rooms.
+func (c *Client) LivechatCustomFieldsList() (*LivechatCustomFieldsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/custom-fields", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatCustomFieldsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatTagsList lists the tags, filtered by name if text is set.
+func (c *Client) LivechatTagsList(text string) (*LivechatTagsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/tags", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if text != "" {
+		url := req.URL.Query()
+		url.Add("text", text)
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := LivechatTagsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatTagsGet gets a tag by id.
+func (c *Client) LivechatTagsGet(tagID string) (*LivechatTagResponse, error) {
+	if tagID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/tags/%s", c.baseURL, c.apiVersion, url.PathEscape(tagID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatTagResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatTagsSave creates or updates a tag. The server has no REST endpoint
+// for it, the livechat:saveTag method is called instead.
+func (c *Client) LivechatTagsSave(param *LivechatTagRequest) error {
+	if param.Name == "" {
+		return fmt.Errorf("false parameters")
+	}
+
+	departments := param.Departments
+	if departments == nil {
+		departments = []string{}
+	}
+
+	_, err := c.CallMethod(context.Background(), "livechat:saveTag", param.ID, map[string]string{
+		"name":        param.Name,
+		"description": param.Description,
+	}, departments)
+	return err
+}
+
+// LivechatTagsRemove removes a tag with the livechat:removeTag method.
+func (c *Client) LivechatTagsRemove(tagID string) error {
+	if tagID == "" {
+		return fmt.Errorf("false parameters")
+	}
+
+	_, err := c.CallMethod(context.Background(), "livechat:removeTag", tagID)
+	return err
+}
+
+// LivechatUnitsList lists the units, filtered by name if text is set.
+func (c *Client) LivechatUnitsList(text string) (*LivechatUnitsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/units", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if text != "" {
+		url := req.URL.Query()
+		url.Add("text", text)
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := LivechatUnitsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUnitsGet gets a unit by id.
+func (c *Client) LivechatUnitsGet(unitID string) (*LivechatUnitResponse, error) {
+	if unitID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/units/%s", c.baseURL, c.apiVersion, url.PathEscape(unitID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUnitResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUnitsCreate creates a unit with its monitors and departments.
+func (c *Client) LivechatUnitsCreate(param *LivechatUnitRequest) (*LivechatUnitResponse, error) {
+	return c.saveLivechatUnit(fmt.Sprintf("%s/%s/livechat/units", c.baseURL, c.apiVersion), param)
+}
+
+// LivechatUnitsUpdate updates a unit, its monitors and departments are replaced.
+func (c *Client) LivechatUnitsUpdate(unitID string, param *LivechatUnitRequest) (*LivechatUnitResponse, error) {
+	if unitID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	return c.saveLivechatUnit(fmt.Sprintf("%s/%s/livechat/units/%s", c.baseURL, c.apiVersion, url.PathEscape(unitID)), param)
+}
+
+func (c *Client) saveLivechatUnit(endpoint string, param *LivechatUnitRequest) (*LivechatUnitResponse, error) {
+	unit := *param
+	if unit.UnitMonitors == nil {
+		unit.UnitMonitors = []LivechatUnitMonitor{}
+	}
+	if unit.UnitDepartments == nil {
+		unit.UnitDepartments = []LivechatUnitDepartment{}
+	}
+	opt, _ := json.Marshal(unit)
+
+	req, err := http.NewRequest("POST",
+		endpoint,
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUnitResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUnitsDelete deletes a unit.
+func (c *Client) LivechatUnitsDelete(unitID string) (*SimpleSuccessResponse, error) {
+	if unitID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("DELETE",
+		fmt.Sprintf("%s/%s/livechat/units/%s", c.baseURL, c.apiVersion, url.PathEscape(unitID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUnitMonitors lists the monitors of a unit.
+func (c *Client) LivechatUnitMonitors(unitID string) (*LivechatUnitMonitorsResponse, error) {
+	if unitID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/units/%s/monitors", c.baseURL, c.apiVersion, url.PathEscape(unitID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatUnitMonitorsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatUnitDepartments lists the departments of a unit.
+func (c *Client) LivechatUnitDepartments(unitID string) (*LivechatDepartmentsResponse, error) {
+	if unitID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/units/%s/departments", c.baseURL, c.apiVersion, url.PathEscape(unitID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatDepartmentsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatPrioritiesList lists the priorities, filtered by name if text is set.
+func (c *Client) LivechatPrioritiesList(text string) (*LivechatPrioritiesResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/priorities", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if text != "" {
+		url := req.URL.Query()
+		url.Add("text", text)
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := LivechatPrioritiesResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatPrioritiesGet gets a priority by id.
+func (c *Client) LivechatPrioritiesGet(priorityID string) (*LivechatPriorityResponse, error) {
+	if priorityID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/livechat/priorities/%s", c.baseURL, c.apiVersion, url.PathEscape(priorityID)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := LivechatPriorityResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatPrioritiesUpdate renames a priority. The set of priorities is fixed.
+func (c *Client) LivechatPrioritiesUpdate(priorityID, name string) (*SimpleSuccessResponse, error) {
+	if priorityID == "" || name == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(map[string]string{"name": name})
+
+	req, err := http.NewRequest("PUT",
+		fmt.Sprintf("%s/%s/livechat/priorities/%s", c.baseURL, c.apiVersion, url.PathEscape(priorityID)),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// LivechatPrioritiesReset restores the default names of the priorities.
+func (c *Client) LivechatPrioritiesReset() (*SimpleSuccessResponse, error) {
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/livechat/priorities.reset", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// query returns the range as the JSON query parameter of livechat/rooms.
+func (d DateRange) query() string {
+	if d.Start.IsZero() && d.End.IsZero() {
+		return ""
+	}
+
+	v := map[string]string{}
+	if !d.Start.IsZero() {
+		v["start"] = d.Start.UTC().Format(time.RFC3339Nano)
+	}
+	if !d.End.IsZero() {
+		v["end"] = d.End.UTC().Format(time.RFC3339Nano)
+	}
+	b, _ := json.Marshal(v)
+
+	return string(b)
+}
+
+func isLivechatUserType(userType string) bool {
+	return userType == LivechatUserTypeAgent || userType == LivechatUserTypeManager
+}
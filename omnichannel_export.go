@@ -0,0 +1,513 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+)
+
+const omnichannelPageSize = 100
+
+// OmnichannelCatalog holds canned responses, tags, units and priorities in a
+// form that can be kept in a JSON file. Server ids are left out, except for
+// priorities which can't be created, so the file can be applied to another
+// server. Department ids are kept as they are. Canned responses of the user
+// scope are left out: they belong to an agent, and the server only creates
+// them for the logged in user.
+type OmnichannelCatalog struct {
+	CannedResponses []CannedResponseRequest `json:"cannedResponses"`
+	Tags            []LivechatTagRequest    `json:"tags"`
+	Units           []OmnichannelUnit       `json:"units"`
+	Priorities      []OmnichannelPriority   `json:"priorities"`
+}
+
+type OmnichannelUnit struct {
+	Name        string                `json:"name"`
+	Visibility  string                `json:"visibility"`
+	Monitors    []LivechatUnitMonitor `json:"monitors"`
+	Departments []string              `json:"departments"`
+}
+
+type OmnichannelPriority struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// OmnichannelImportResult counts the changes made by ImportOmnichannel.
+type OmnichannelImportResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// LoadOmnichannelCatalog reads a catalog written by WriteOmnichannelCatalog.
+func LoadOmnichannelCatalog(path string) (*OmnichannelCatalog, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := OmnichannelCatalog{}
+	if err := json.Unmarshal(b, &catalog); err != nil {
+		return nil, fmt.Errorf("parse omnichannel catalog %s: %w", path, err)
+	}
+
+	return &catalog, nil
+}
+
+// WriteOmnichannelCatalog writes the catalog as indented JSON.
+func WriteOmnichannelCatalog(w io.Writer, catalog *OmnichannelCatalog) error {
+	b, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// ExportOmnichannel reads the canned responses, tags, units and priorities
+// of the server. Every list is sorted, so exports of the same data are equal.
+func (c *Client) ExportOmnichannel() (*OmnichannelCatalog, error) {
+	catalog := &OmnichannelCatalog{
+		CannedResponses: []CannedResponseRequest{},
+		Tags:            []LivechatTagRequest{},
+		Units:           []OmnichannelUnit{},
+		Priorities:      []OmnichannelPriority{},
+	}
+
+	responses, err := c.allCannedResponses()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range responses {
+		if r.Scope == CannedResponseScopeUser {
+			continue
+		}
+		catalog.CannedResponses = append(catalog.CannedResponses, cannedResponseRequest(r))
+	}
+
+	tags, err := c.allLivechatTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		catalog.Tags = append(catalog.Tags, livechatTagRequest(t))
+	}
+
+	units, err := c.allLivechatUnits()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range units {
+		unit, err := c.exportLivechatUnit(u)
+		if err != nil {
+			return nil, err
+		}
+		catalog.Units = append(catalog.Units, unit)
+	}
+
+	priorities, err := c.allLivechatPriorities()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range priorities {
+		catalog.Priorities = append(catalog.Priorities, OmnichannelPriority{ID: p.ID, Name: p.Name})
+	}
+
+	catalog.sort()
+	return catalog, nil
+}
+
+// ImportOmnichannel creates and updates the canned responses, tags, units and
+// priorities of the catalog. Canned responses are matched by scope,
+// department and shortcut, tags and units by name and priorities by id.
+// Canned responses of the user scope are skipped. Nothing is deleted from
+// the server.
+func (c *Client) ImportOmnichannel(catalog *OmnichannelCatalog) (*OmnichannelImportResult, error) {
+	result := &OmnichannelImportResult{}
+
+	if err := c.importCannedResponses(catalog.CannedResponses, result); err != nil {
+		return result, err
+	}
+	if err := c.importLivechatTags(catalog.Tags, result); err != nil {
+		return result, err
+	}
+	if err := c.importLivechatUnits(catalog.Units, result); err != nil {
+		return result, err
+	}
+	if err := c.importLivechatPriorities(catalog.Priorities, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) importCannedResponses(responses []CannedResponseRequest, result *OmnichannelImportResult) error {
+	current, err := c.allCannedResponses()
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]CannedResponse{}
+	for _, r := range current {
+		if r.Scope != CannedResponseScopeUser {
+			existing[cannedResponseKey(r.Scope, r.DepartmentID, r.Shortcut)] = r
+		}
+	}
+
+	for _, want := range responses {
+		if want.Scope == CannedResponseScopeUser {
+			continue
+		}
+		want.ID = ""
+
+		have, ok := existing[cannedResponseKey(want.Scope, want.DepartmentID, want.Shortcut)]
+		switch {
+		case !ok:
+			res, err := c.CannedResponsesCreate(&want)
+			if err := checkImport(res, err, "canned response", want.Shortcut); err != nil {
+				return err
+			}
+			result.Created++
+		case !reflect.DeepEqual(cannedResponseRequest(have), normalizeCannedResponse(want)):
+			res, err := c.CannedResponsesUpdate(have.ID, &want)
+			if err := checkImport(res, err, "canned response", want.Shortcut); err != nil {
+				return err
+			}
+			result.Updated++
+		default:
+			result.Unchanged++
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) importLivechatTags(tags []LivechatTagRequest, result *OmnichannelImportResult) error {
+	current, err := c.allLivechatTags()
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]LivechatTag{}
+	for _, t := range current {
+		existing[t.Name] = t
+	}
+
+	for _, want := range tags {
+		want.ID = ""
+
+		have, ok := existing[want.Name]
+		if ok && reflect.DeepEqual(livechatTagRequest(have), normalizeLivechatTag(want)) {
+			result.Unchanged++
+			continue
+		}
+
+		want.ID = have.ID
+		if err := c.LivechatTagsSave(&want); err != nil {
+			return fmt.Errorf("import tag %s: %w", want.Name, err)
+		}
+		if ok {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) importLivechatUnits(units []OmnichannelUnit, result *OmnichannelImportResult) error {
+	current, err := c.allLivechatUnits()
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]LivechatUnit{}
+	for _, u := range current {
+		existing[u.Name] = u
+	}
+
+	for _, want := range units {
+		req := &LivechatUnitRequest{
+			UnitData:     LivechatUnitData{Name: want.Name, Visibility: want.Visibility},
+			UnitMonitors: want.Monitors,
+		}
+		for _, id := range want.Departments {
+			req.UnitDepartments = append(req.UnitDepartments, LivechatUnitDepartment{DepartmentID: id})
+		}
+
+		have, ok := existing[want.Name]
+		if !ok {
+			res, err := c.LivechatUnitsCreate(req)
+			if err == nil && !res.Success {
+				err = fmt.Errorf("%s", res.Error)
+			}
+			if err != nil {
+				return fmt.Errorf("import unit %s: %w", want.Name, err)
+			}
+			result.Created++
+			continue
+		}
+
+		unit, err := c.exportLivechatUnit(have)
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(unit, normalizeOmnichannelUnit(want)) {
+			result.Unchanged++
+			continue
+		}
+
+		res, err := c.LivechatUnitsUpdate(have.ID, req)
+		if err == nil && !res.Success {
+			err = fmt.Errorf("%s", res.Error)
+		}
+		if err != nil {
+			return fmt.Errorf("import unit %s: %w", want.Name, err)
+		}
+		result.Updated++
+	}
+
+	return nil
+}
+
+func (c *Client) importLivechatPriorities(priorities []OmnichannelPriority, result *OmnichannelImportResult) error {
+	current, err := c.allLivechatPriorities()
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]LivechatPriority{}
+	for _, p := range current {
+		existing[p.ID] = p
+	}
+
+	for _, want := range priorities {
+		have, ok := existing[want.ID]
+		if !ok {
+			return fmt.Errorf("import priority %s: not found on server", want.ID)
+		}
+		if have.Name == want.Name || want.Name == "" {
+			result.Unchanged++
+			continue
+		}
+
+		res, err := c.LivechatPrioritiesUpdate(want.ID, want.Name)
+		if err := checkImport(res, err, "priority", want.ID); err != nil {
+			return err
+		}
+		result.Updated++
+	}
+
+	return nil
+}
+
+func (c *Client) allCannedResponses() ([]CannedResponse, error) {
+	all := []CannedResponse{}
+	err := eachPage(func(offset int) (int, int, error) {
+		res, err := c.Count(omnichannelPageSize).Offset(offset).CannedResponsesList(&CannedResponsesRequest{})
+		if err != nil {
+			return 0, 0, err
+		}
+		if !res.Success {
+			return 0, 0, fmt.Errorf("list canned responses failed: %s", res.Error)
+		}
+		all = append(all, res.CannedResponses...)
+		return len(res.CannedResponses), res.Total, nil
+	})
+
+	return all, err
+}
+
+func (c *Client) allLivechatTags() ([]LivechatTag, error) {
+	all := []LivechatTag{}
+	err := eachPage(func(offset int) (int, int, error) {
+		res, err := c.Count(omnichannelPageSize).Offset(offset).LivechatTagsList("")
+		if err != nil {
+			return 0, 0, err
+		}
+		if !res.Success {
+			return 0, 0, fmt.Errorf("list tags failed: %s", res.Error)
+		}
+		all = append(all, res.Tags...)
+		return len(res.Tags), res.Total, nil
+	})
+
+	return all, err
+}
+
+func (c *Client) allLivechatUnits() ([]LivechatUnit, error) {
+	all := []LivechatUnit{}
+	err := eachPage(func(offset int) (int, int, error) {
+		res, err := c.Count(omnichannelPageSize).Offset(offset).LivechatUnitsList("")
+		if err != nil {
+			return 0, 0, err
+		}
+		if !res.Success {
+			return 0, 0, fmt.Errorf("list units failed: %s", res.Error)
+		}
+		all = append(all, res.Units...)
+		return len(res.Units), res.Total, nil
+	})
+
+	return all, err
+}
+
+func (c *Client) allLivechatPriorities() ([]LivechatPriority, error) {
+	all := []LivechatPriority{}
+	err := eachPage(func(offset int) (int, int, error) {
+		res, err := c.Count(omnichannelPageSize).Offset(offset).LivechatPrioritiesList("")
+		if err != nil {
+			return 0, 0, err
+		}
+		if !res.Success {
+			return 0, 0, fmt.Errorf("list priorities failed: %s", res.Error)
+		}
+		all = append(all, res.Priorities...)
+		return len(res.Priorities), res.Total, nil
+	})
+
+	return all, err
+}
+
+func (c *Client) exportLivechatUnit(u LivechatUnit) (OmnichannelUnit, error) {
+	unit := OmnichannelUnit{Name: u.Name, Visibility: u.Visibility, Monitors: []LivechatUnitMonitor{}, Departments: []string{}}
+
+	monitors, err := c.LivechatUnitMonitors(u.ID)
+	if err != nil {
+		return unit, err
+	}
+	if !monitors.Success {
+		return unit, fmt.Errorf("list monitors of unit %s failed: %s", u.Name, monitors.Error)
+	}
+	for _, m := range monitors.Monitors {
+		unit.Monitors = append(unit.Monitors, LivechatUnitMonitor{MonitorID: m.MonitorID, Username: m.Username})
+	}
+
+	err = eachPage(func(offset int) (int, int, error) {
+		res, err := c.Count(omnichannelPageSize).Offset(offset).LivechatUnitDepartments(u.ID)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !res.Success {
+			return 0, 0, fmt.Errorf("list departments of unit %s failed: %s", u.Name, res.Error)
+		}
+		for _, d := range res.Departments {
+			unit.Departments = append(unit.Departments, d.ID)
+		}
+		return len(res.Departments), res.Total, nil
+	})
+	if err != nil {
+		return unit, err
+	}
+
+	return normalizeOmnichannelUnit(unit), nil
+}
+
+// eachPage calls page with increasing offsets until all items are read.
+// page returns the number of items read and the total.
+func eachPage(page func(offset int) (int, int, error)) error {
+	for offset := 0; ; {
+		n, total, err := page(offset)
+		if err != nil {
+			return err
+		}
+
+		offset += n
+		if n == 0 || offset >= total {
+			return nil
+		}
+	}
+}
+
+func checkImport(res *SimpleSuccessResponse, err error, kind, name string) error {
+	if err == nil && !res.Success {
+		err = fmt.Errorf("%s", res.Error)
+	}
+	if err != nil {
+		return fmt.Errorf("import %s %s: %w", kind, name, err)
+	}
+
+	return nil
+}
+
+func cannedResponseKey(scope, departmentID, shortcut string) string {
+	return scope + "/" + departmentID + "/" + shortcut
+}
+
+func cannedResponseRequest(r CannedResponse) CannedResponseRequest {
+	return normalizeCannedResponse(CannedResponseRequest{
+		Shortcut:     r.Shortcut,
+		Text:         r.Text,
+		Scope:        r.Scope,
+		Tags:         r.Tags,
+		DepartmentID: r.DepartmentID,
+	})
+}
+
+func normalizeCannedResponse(r CannedResponseRequest) CannedResponseRequest {
+	r.ID = ""
+	r.Tags = sortedStrings(r.Tags)
+	return r
+}
+
+func livechatTagRequest(t LivechatTag) LivechatTagRequest {
+	return normalizeLivechatTag(LivechatTagRequest{
+		Name:        t.Name,
+		Description: t.Description,
+		Departments: t.Departments,
+	})
+}
+
+func normalizeLivechatTag(t LivechatTagRequest) LivechatTagRequest {
+	t.ID = ""
+	t.Departments = sortedStrings(t.Departments)
+	return t
+}
+
+func normalizeOmnichannelUnit(u OmnichannelUnit) OmnichannelUnit {
+	monitors := append([]LivechatUnitMonitor{}, u.Monitors...)
+	sort.Slice(monitors, func(i, j int) bool {
+		return monitors[i].Username < monitors[j].Username
+	})
+	u.Monitors = monitors
+
+	u.Departments = sortedStrings(u.Departments)
+	if u.Departments == nil {
+		u.Departments = []string{}
+	}
+
+	return u
+}
+
+// sortedStrings returns a sorted copy of s, nil if s is empty.
+func sortedStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func (catalog *OmnichannelCatalog) sort() {
+	sort.SliceStable(catalog.CannedResponses, func(i, j int) bool {
+		a, b := catalog.CannedResponses[i], catalog.CannedResponses[j]
+		return cannedResponseKey(a.Scope, a.DepartmentID, a.Shortcut) < cannedResponseKey(b.Scope, b.DepartmentID, b.Shortcut)
+	})
+	sort.SliceStable(catalog.Tags, func(i, j int) bool {
+		return catalog.Tags[i].Name < catalog.Tags[j].Name
+	})
+	sort.SliceStable(catalog.Units, func(i, j int) bool {
+		return catalog.Units[i].Name < catalog.Units[j].Name
+	})
+	sort.SliceStable(catalog.Priorities, func(i, j int) bool {
+		return catalog.Priorities[i].ID < catalog.Priorities[j].ID
+	})
+}
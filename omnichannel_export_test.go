@@ -0,0 +1,178 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// omnichannelServer serves the lists of the catalog and records the writes.
+type omnichannelServer struct {
+	t      *testing.T
+	mu     sync.Mutex
+	writes []string
+}
+
+func (s *omnichannelServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		b, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.writes = append(s.writes, r.Method+" "+r.URL.Path+" "+string(b))
+		s.mu.Unlock()
+
+		if strings.HasPrefix(r.URL.Path, "/api/v1/method.call/") {
+			w.Write([]byte(`{"message":"{\"msg\":\"result\",\"id\":\"1\",\"result\":true}","success":true}`))
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/v1/canned-responses":
+		// two pages to check that every page is read
+		if offset := r.URL.Query().Get("offset"); offset == "" || offset == "0" {
+			w.Write([]byte(`{"cannedResponses":[{"_id":"cr1","shortcut":"refund","text":"Refunds take 5 days","scope":"department","tags":["billing","money"],"departmentId":"sales"}],"count":1,"offset":0,"total":2,"success":true}`))
+			return
+		}
+		w.Write([]byte(`{"cannedResponses":[{"_id":"cr2","shortcut":"hi","text":"Hello!","scope":"global"}],"count":1,"offset":1,"total":2,"success":true}`))
+	case "/api/v1/livechat/tags":
+		w.Write([]byte(`{"tags":[{"_id":"t1","name":"billing","description":"Money","departments":["sales"]}],"count":1,"offset":0,"total":1,"success":true}`))
+	case "/api/v1/livechat/units":
+		w.Write([]byte(`{"units":[{"_id":"u1","name":"EMEA","visibility":"public"}],"count":1,"offset":0,"total":1,"success":true}`))
+	case "/api/v1/livechat/units/u1/monitors":
+		w.Write([]byte(`{"monitors":[{"_id":"m1","monitorId":"9HLkTyQqbpf3Cc9pw","username":"john","unitId":"u1"}],"success":true}`))
+	case "/api/v1/livechat/units/u1/departments":
+		w.Write([]byte(`{"departments":[{"_id":"sales","name":"Sales"}],"count":1,"offset":0,"total":1,"success":true}`))
+	case "/api/v1/livechat/priorities":
+		w.Write([]byte(`{"priorities":[{"_id":"p1","i18n":"Lowest","sortItem":1},{"_id":"p5","name":"Urgent","i18n":"Highest","sortItem":5,"dirty":true}],"count":2,"offset":0,"total":2,"success":true}`))
+	default:
+		s.t.Errorf("unexpected request %s", r.URL.Path)
+	}
+}
+
+func TestExportOmnichannel(t *testing.T) {
+	server := httptest.NewServer(&omnichannelServer{t: t})
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	catalog, err := client.ExportOmnichannel()
+	require.NoError(t, err)
+
+	require.Equal(t, &OmnichannelCatalog{
+		CannedResponses: []CannedResponseRequest{
+			{Shortcut: "refund", Text: "Refunds take 5 days", Scope: "department", Tags: []string{"billing", "money"}, DepartmentID: "sales"},
+			{Shortcut: "hi", Text: "Hello!", Scope: "global"},
+		},
+		Tags: []LivechatTagRequest{{Name: "billing", Description: "Money", Departments: []string{"sales"}}},
+		Units: []OmnichannelUnit{{
+			Name:        "EMEA",
+			Visibility:  "public",
+			Monitors:    []LivechatUnitMonitor{{MonitorID: "9HLkTyQqbpf3Cc9pw", Username: "john"}},
+			Departments: []string{"sales"},
+		}},
+		Priorities: []OmnichannelPriority{{ID: "p1"}, {ID: "p5", Name: "Urgent"}},
+	}, catalog)
+
+	dir, err := ioutil.TempDir("", "gorocket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "omnichannel.json")
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteOmnichannelCatalog(buf, catalog))
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+
+	loaded, err := LoadOmnichannelCatalog(path)
+	require.NoError(t, err)
+	require.Equal(t, catalog, loaded)
+}
+
+func TestImportOmnichannel(t *testing.T) {
+	recorder := &omnichannelServer{t: t}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	catalog, err := client.ExportOmnichannel()
+	require.NoError(t, err)
+
+	// unchanged catalog, nothing is written
+	result, err := client.ImportOmnichannel(catalog)
+	require.NoError(t, err)
+	require.Equal(t, &OmnichannelImportResult{Unchanged: 6}, result)
+	require.Empty(t, recorder.writes)
+
+	catalog.CannedResponses[1].Text = "Hi there!"
+	catalog.CannedResponses = append(catalog.CannedResponses, CannedResponseRequest{Shortcut: "bye", Text: "Bye!", Scope: "global"})
+	catalog.Tags[0].Departments = []string{"sales", "support"}
+	catalog.Units[0].Visibility = "private"
+	catalog.Priorities[1].Name = "Very urgent"
+
+	result, err = client.ImportOmnichannel(catalog)
+	require.NoError(t, err)
+	require.Equal(t, &OmnichannelImportResult{Created: 1, Updated: 4, Unchanged: 2}, result)
+
+	require.Equal(t, 5, len(recorder.writes))
+	require.Equal(t, `POST /api/v1/canned-responses {"_id":"cr2","shortcut":"hi","text":"Hi there!","scope":"global"}`, recorder.writes[0])
+	require.Equal(t, `POST /api/v1/canned-responses {"shortcut":"bye","text":"Bye!","scope":"global"}`, recorder.writes[1])
+	require.True(t, strings.HasPrefix(recorder.writes[2], "POST /api/v1/method.call/livechat:saveTag "))
+
+	message := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(recorder.writes[2], "POST /api/v1/method.call/livechat:saveTag ")), &message))
+	call := methodCall{}
+	require.NoError(t, json.Unmarshal([]byte(message["message"]), &call))
+	require.Equal(t, []interface{}{"t1", map[string]interface{}{"name": "billing", "description": "Money"}, []interface{}{"sales", "support"}}, call.Params)
+
+	require.Equal(t, `POST /api/v1/livechat/units/u1 {"unitData":{"name":"EMEA","visibility":"private"},"unitMonitors":[{"monitorId":"9HLkTyQqbpf3Cc9pw","username":"john"}],"unitDepartments":[{"departmentId":"sales"}]}`, recorder.writes[3])
+	require.Equal(t, `PUT /api/v1/livechat/priorities/p5 {"name":"Very urgent"}`, recorder.writes[4])
+}
+
+func TestOmnichannelUserCannedResponses(t *testing.T) {
+	var writes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			writes = append(writes, r.URL.Path)
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/canned-responses":
+			w.Write([]byte(`{"cannedResponses":[
+				{"_id":"cr1","shortcut":"hi","text":"Hello from John","scope":"user","userId":"u1"},
+				{"_id":"cr2","shortcut":"hi","text":"Hello from Jane","scope":"user","userId":"u2"},
+				{"_id":"cr3","shortcut":"hi","text":"Hello!","scope":"global"}
+			],"count":3,"offset":0,"total":3,"success":true}`))
+		case "/api/v1/livechat/tags":
+			w.Write([]byte(`{"tags":[],"count":0,"offset":0,"total":0,"success":true}`))
+		case "/api/v1/livechat/units":
+			w.Write([]byte(`{"units":[],"count":0,"offset":0,"total":0,"success":true}`))
+		case "/api/v1/livechat/priorities":
+			w.Write([]byte(`{"priorities":[],"count":0,"offset":0,"total":0,"success":true}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	catalog, err := client.ExportOmnichannel()
+	require.NoError(t, err)
+	require.Equal(t, []CannedResponseRequest{{Shortcut: "hi", Text: "Hello!", Scope: "global"}}, catalog.CannedResponses)
+
+	catalog.CannedResponses = append(catalog.CannedResponses, CannedResponseRequest{Shortcut: "hi", Text: "Hello from Bob", Scope: CannedResponseScopeUser})
+	result, err := client.ImportOmnichannel(catalog)
+	require.NoError(t, err)
+	require.Equal(t, &OmnichannelImportResult{Unchanged: 1}, result)
+	require.Empty(t, writes)
+}
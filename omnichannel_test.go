@@ -0,0 +1,528 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivechatUsersList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		require.Equal(t, "/api/v1/livechat/users/agent", r.URL.Path)
+		require.Equal(t, "10", r.URL.Query().Get("count"))
+
+		w.Write([]byte(`{"users":[{"_id":"9HLkTyQqbpf3Cc9pw","username":"john","name":"John","status":"online","statusLivechat":"available","roles":["user","livechat-agent"]}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.Count(10).LivechatUsersList(LivechatUserTypeAgent)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "available", resp.Users[0].StatusLivechat)
+
+	_, err = client.LivechatUsersList("admin")
+	require.Error(t, err)
+}
+
+func TestLivechatUsersAdd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/users/manager", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "john", body["username"])
+
+		w.Write([]byte(`{"user":{"_id":"9HLkTyQqbpf3Cc9pw","username":"john"},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUsersAdd(LivechatUserTypeManager, "john")
+	require.NoError(t, err)
+	require.Equal(t, "9HLkTyQqbpf3Cc9pw", resp.User.ID)
+}
+
+func TestLivechatUsersGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/users/agent/9HLkTyQqbpf3Cc9pw", r.URL.Path)
+
+		w.Write([]byte(`{"user":{"_id":"9HLkTyQqbpf3Cc9pw","username":"john","emails":[{"address":"john@example.com","verified":true}]},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUsersGet(LivechatUserTypeAgent, "9HLkTyQqbpf3Cc9pw")
+	require.NoError(t, err)
+	require.Equal(t, "john@example.com", resp.User.Emails[0].Address)
+}
+
+func TestLivechatUsersRemove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "DELETE", r.Method)
+		require.Equal(t, "/api/v1/livechat/users/agent/9HLkTyQqbpf3Cc9pw", r.URL.Path)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUsersRemove(LivechatUserTypeAgent, "9HLkTyQqbpf3Cc9pw")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestLivechatDepartmentList(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"departments":[{"_id":"sales","enabled":true,"name":"Sales","email":"sales@example.com","showOnRegistration":true,"showOnOfflineForm":false,"numAgents":2,"_updatedAt":"2018-03-01T18:02:26.828Z"}],"count":1,"offset":0,"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentList()
+	require.NoError(t, err)
+	require.Equal(t, "Sales", resp.Departments[0].Name)
+	require.Equal(t, 2, resp.Departments[0].NumAgents)
+}
+
+func TestLivechatDepartmentCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/department", r.URL.Path)
+
+		body := LivechatDepartmentRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "Sales", body.Department.Name)
+		require.Equal(t, "john", body.Agents[0].Username)
+
+		w.Write([]byte(`{"department":{"_id":"sales","enabled":true,"name":"Sales"},"agents":[{"_id":"a1","agentId":"9HLkTyQqbpf3Cc9pw","departmentId":"sales","username":"john","count":0,"order":0}],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentCreate(&LivechatDepartmentRequest{
+		Department: LivechatDepartmentData{Enabled: true, Name: "Sales", Email: "sales@example.com", ShowOnRegistration: true},
+		Agents:     []LivechatDepartmentAgent{{AgentID: "9HLkTyQqbpf3Cc9pw", Username: "john"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "sales", resp.Department.ID)
+	require.Equal(t, "sales", resp.Agents[0].DepartmentID)
+}
+
+func TestLivechatDepartmentGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/department/sales", r.URL.Path)
+
+		w.Write([]byte(`{"department":{"_id":"sales","name":"Sales"},"agents":[],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentGet("sales")
+	require.NoError(t, err)
+	require.Equal(t, "Sales", resp.Department.Name)
+}
+
+func TestLivechatDepartmentUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "/api/v1/livechat/department/sales", r.URL.Path)
+
+		w.Write([]byte(`{"department":{"_id":"sales","name":"Sales EMEA"},"agents":[],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentUpdate("sales", &LivechatDepartmentRequest{
+		Department: LivechatDepartmentData{Enabled: true, Name: "Sales EMEA", Email: "sales@example.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Sales EMEA", resp.Department.Name)
+
+	_, err = client.LivechatDepartmentUpdate("", &LivechatDepartmentRequest{})
+	require.Error(t, err)
+}
+
+func TestLivechatDepartmentDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "DELETE", r.Method)
+		require.Equal(t, "/api/v1/livechat/department/sales", r.URL.Path)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentDelete("sales")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestLivechatDepartmentAgents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/department/sales/agents", r.URL.Path)
+
+		w.Write([]byte(`{"agents":[{"_id":"a1","agentId":"9HLkTyQqbpf3Cc9pw","departmentId":"sales","username":"john","count":3,"order":1}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentAgents("sales")
+	require.NoError(t, err)
+	require.Equal(t, 3, resp.Agents[0].Count)
+	require.Equal(t, 1, resp.Agents[0].Order)
+}
+
+func TestLivechatDepartmentUpdateAgents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/department/sales/agents", r.URL.Path)
+
+		body := map[string][]LivechatDepartmentAgent{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "jane", body["upsert"][0].Username)
+		require.NotNil(t, body["remove"])
+		require.Empty(t, body["remove"])
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatDepartmentUpdateAgents("sales", &LivechatDepartmentAgentsUpdate{
+		Upsert: []LivechatDepartmentAgent{{AgentID: "u2", Username: "jane"}},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestLivechatInquiriesList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/inquiries.list", r.URL.Path)
+		require.Equal(t, "sales", r.URL.Query().Get("department"))
+
+		w.Write([]byte(`{"inquiries":[{"_id":"inq1","rid":"zRAeTszXor8CCPbm3","name":"Jane","message":"Hello","status":"queued","department":"sales","token":"visitor-token","v":{"_id":"q5L2dTqtAE4p2yfk6","username":"guest-2"},"ts":"2018-03-01T18:02:26.825Z"}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatInquiriesList("sales")
+	require.NoError(t, err)
+	require.Equal(t, "queued", resp.Inquiries[0].Status)
+	require.Equal(t, "guest-2", resp.Inquiries[0].Visitor.Username)
+}
+
+func TestLivechatInquiriesTake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/inquiries.take", r.URL.Path)
+
+		body := TakeInquiryRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "inq1", body.InquiryID)
+
+		w.Write([]byte(`{"inquiry":{"_id":"inq1","rid":"zRAeTszXor8CCPbm3","status":"taken"},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatInquiriesTake(&TakeInquiryRequest{InquiryID: "inq1"})
+	require.NoError(t, err)
+	require.Equal(t, "taken", resp.Inquiry.Status)
+
+	_, err = client.LivechatInquiriesTake(&TakeInquiryRequest{})
+	require.Error(t, err)
+}
+
+func TestLivechatRooms(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/rooms", r.URL.Path)
+
+		query := r.URL.Query()
+		require.Equal(t, "false", query.Get("open"))
+		require.Equal(t, []string{"agent1", "agent2"}, query["agents[]"])
+		require.Equal(t, "sales", query.Get("departmentId"))
+		require.Equal(t, `{"end":"2018-03-31T00:00:00Z","start":"2018-03-01T00:00:00Z"}`, query.Get("createdAt"))
+		require.Empty(t, query.Get("closedAt"))
+		require.Equal(t, `{"plan":"pro"}`, query.Get("customFields"))
+
+		w.Write([]byte(`{"rooms":[{"_id":"zRAeTszXor8CCPbm3","t":"l","fname":"Jane","open":false,"departmentId":"sales","tags":["refund"],"livechatData":{"plan":"pro"},"v":{"_id":"q5L2dTqtAE4p2yfk6","token":"visitor-token","username":"guest-2"},"closedAt":"2018-03-02T10:00:00.000Z","closedBy":{"_id":"agent1","username":"john"}}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	open := false
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatRooms(&LivechatRoomsRequest{
+		Open:         &open,
+		Agents:       []string{"agent1", "agent2"},
+		DepartmentID: "sales",
+		CreatedAt: DateRange{
+			Start: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2018, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		CustomFields: map[string]string{"plan": "pro"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.False(t, resp.Rooms[0].Open)
+	require.Equal(t, []string{"refund"}, resp.Rooms[0].Tags)
+	require.Equal(t, "john", resp.Rooms[0].ClosedBy.Username)
+}
+
+func TestLivechatCustomFieldsList(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"customFields":[{"_id":"plan","label":"Plan","scope":"visitor","visibility":"visible","regexp":""}],"count":1,"offset":0,"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatCustomFieldsList()
+	require.NoError(t, err)
+	require.Equal(t, "Plan", resp.CustomFields[0].Label)
+}
+
+func TestLivechatTagsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/tags", r.URL.Path)
+		require.Equal(t, "bill", r.URL.Query().Get("text"))
+
+		w.Write([]byte(`{"tags":[{"_id":"t1","name":"billing","description":"Money","departments":["sales"]}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatTagsList("bill")
+	require.NoError(t, err)
+	require.Equal(t, []string{"sales"}, resp.Tags[0].Departments)
+}
+
+func TestLivechatTagsGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/tags/t1", r.URL.Path)
+
+		w.Write([]byte(`{"_id":"t1","name":"billing","departments":[],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatTagsGet("t1")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, "billing", resp.Name)
+}
+
+func TestLivechatTagsSave(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/method.call/livechat:saveTag", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		call := methodCall{}
+		require.NoError(t, json.Unmarshal([]byte(body["message"]), &call))
+		require.Equal(t, "method", call.Msg)
+		require.Equal(t, "livechat:saveTag", call.Method)
+		require.Equal(t, []interface{}{"", map[string]interface{}{"name": "billing", "description": ""}, []interface{}{}}, call.Params)
+
+		w.Write([]byte(`{"message":"{\"msg\":\"result\",\"id\":\"` + call.ID + `\",\"result\":{\"_id\":\"t1\"}}","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	require.NoError(t, client.LivechatTagsSave(&LivechatTagRequest{Name: "billing"}))
+}
+
+func TestLivechatTagsRemove(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"message":"{\"msg\":\"result\",\"id\":\"1\",\"error\":{\"isClientSafe\":true,\"error\":\"error-not-allowed\",\"reason\":\"Not allowed\",\"message\":\"Not allowed [error-not-allowed]\",\"errorType\":\"Meteor.Error\"}}","success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	err := client.LivechatTagsRemove("t1")
+	require.EqualError(t, err, "Not allowed [error-not-allowed]")
+
+	methodErr, ok := err.(*MethodError)
+	require.True(t, ok)
+	require.Equal(t, "error-not-allowed", methodErr.Code)
+}
+
+func TestLivechatUnitsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/units", r.URL.Path)
+
+		w.Write([]byte(`{"units":[{"_id":"u1","name":"EMEA","visibility":"public","type":"u","numMonitors":1,"numDepartments":2}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitsList("")
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Units[0].NumDepartments)
+}
+
+func TestLivechatUnitsGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/units/u1", r.URL.Path)
+
+		w.Write([]byte(`{"_id":"u1","name":"EMEA","visibility":"public","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitsGet("u1")
+	require.NoError(t, err)
+	require.Equal(t, "EMEA", resp.Name)
+}
+
+func TestLivechatUnitsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/units", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{
+			"unitData":        map[string]interface{}{"name": "EMEA", "visibility": "public"},
+			"unitMonitors":    []interface{}{},
+			"unitDepartments": []interface{}{map[string]interface{}{"departmentId": "sales"}},
+		}, body)
+
+		w.Write([]byte(`{"_id":"u1","name":"EMEA","visibility":"public","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitsCreate(&LivechatUnitRequest{
+		UnitData:        LivechatUnitData{Name: "EMEA", Visibility: "public"},
+		UnitDepartments: []LivechatUnitDepartment{{DepartmentID: "sales"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "u1", resp.ID)
+}
+
+func TestLivechatUnitsUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/units/u1", r.URL.Path)
+
+		w.Write([]byte(`{"_id":"u1","name":"Europe","visibility":"private","success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitsUpdate("u1", &LivechatUnitRequest{UnitData: LivechatUnitData{Name: "Europe", Visibility: "private"}})
+	require.NoError(t, err)
+	require.Equal(t, "Europe", resp.Name)
+}
+
+func TestLivechatUnitsDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "DELETE", r.Method)
+		require.Equal(t, "/api/v1/livechat/units/u1", r.URL.Path)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitsDelete("u1")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestLivechatUnitMonitors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/units/u1/monitors", r.URL.Path)
+
+		w.Write([]byte(`{"monitors":[{"_id":"m1","monitorId":"9HLkTyQqbpf3Cc9pw","username":"john","unitId":"u1"}],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitMonitors("u1")
+	require.NoError(t, err)
+	require.Equal(t, "john", resp.Monitors[0].Username)
+}
+
+func TestLivechatUnitDepartments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/units/u1/departments", r.URL.Path)
+
+		w.Write([]byte(`{"departments":[{"_id":"sales","name":"Sales"}],"count":1,"offset":0,"total":1,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatUnitDepartments("u1")
+	require.NoError(t, err)
+	require.Equal(t, "sales", resp.Departments[0].ID)
+}
+
+func TestLivechatPrioritiesList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/priorities", r.URL.Path)
+
+		w.Write([]byte(`{"priorities":[{"_id":"p1","i18n":"Lowest","sortItem":1,"dirty":false},{"_id":"p5","name":"Urgent","i18n":"Highest","sortItem":5,"dirty":true}],"count":2,"offset":0,"total":2,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatPrioritiesList("")
+	require.NoError(t, err)
+	require.Equal(t, "Urgent", resp.Priorities[1].Name)
+	require.True(t, resp.Priorities[1].Dirty)
+}
+
+func TestLivechatPrioritiesGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/livechat/priorities/p5", r.URL.Path)
+
+		w.Write([]byte(`{"_id":"p5","name":"Urgent","i18n":"Highest","sortItem":5,"dirty":true,"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatPrioritiesGet("p5")
+	require.NoError(t, err)
+	require.Equal(t, 5, resp.SortItem)
+}
+
+func TestLivechatPrioritiesUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "/api/v1/livechat/priorities/p5", r.URL.Path)
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "Urgent", body["name"])
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatPrioritiesUpdate("p5", "Urgent")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestLivechatPrioritiesReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/api/v1/livechat/priorities.reset", r.URL.Path)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.LivechatPrioritiesReset()
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
@@ -0,0 +1,82 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Permission struct {
+	ID        string    `json:"_id"`
+	Roles     []string  `json:"roles"`
+	UpdatedAt time.Time `json:"_updatedAt"`
+}
+
+type PermissionsListResponse struct {
+	Update  []Permission `json:"update"`
+	Remove  []Permission `json:"remove"`
+	Success bool         `json:"success"`
+}
+
+type PermissionsUpdateRequest struct {
+	Permissions []PermissionRoles `json:"permissions"`
+}
+
+type PermissionRoles struct {
+	ID    string   `json:"_id"`
+	Roles []string `json:"roles"`
+}
+
+type PermissionsUpdateResponse struct {
+	Permissions []Permission `json:"permissions"`
+	Success     bool         `json:"success"`
+}
+
+// PermissionsListAll lists all permissions. If updatedSince is not zero, only
+// the permissions changed since then are returned.
+func (c *Client) PermissionsListAll(updatedSince time.Time) (*PermissionsListResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/permissions.listAll", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !updatedSince.IsZero() {
+		url := req.URL.Query()
+		url.Add("updatedSince", updatedSince.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := PermissionsListResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PermissionsUpdate sets the roles of the given permissions.
+func (c *Client) PermissionsUpdate(param *PermissionsUpdateRequest) (*PermissionsUpdateResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/permissions.update", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := PermissionsUpdateResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,44 @@
+package gorocket
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionsListAll(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"update":[{"_id":"access-permissions","roles":["admin"],"_updatedAt":"2023-10-10T23:40:46.093Z"}],"remove":[],"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.PermissionsListAll(time.Time{})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(resp.Update))
+	require.Equal(t, "access-permissions", resp.Update[0].ID)
+	require.Equal(t, []string{"admin"}, resp.Update[0].Roles)
+	require.Equal(t, 0, len(resp.Remove))
+	require.True(t, resp.Success)
+}
+
+func TestPermissionsUpdate(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"permissions":[{"_id":"access-permissions","roles":["admin","auditor"],"_updatedAt":"2023-10-10T23:40:46.093Z"}],"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.PermissionsUpdate(&PermissionsUpdateRequest{
+		Permissions: []PermissionRoles{
+			{ID: "access-permissions", Roles: []string{"admin", "auditor"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"admin", "auditor"}, resp.Permissions[0].Roles)
+	require.True(t, resp.Success)
+}
@@ -0,0 +1,195 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	UserStatusOffline = "offline"
+	UserStatusOnline  = "online"
+	UserStatusAway    = "away"
+	UserStatusBusy    = "busy"
+)
+
+// userStatuses maps the status codes of the user-status stream to names.
+var userStatuses = []string{UserStatusOffline, UserStatusOnline, UserStatusAway, UserStatusBusy}
+
+// UserStatusEvent is a status change of a user.
+type UserStatusEvent struct {
+	UserID     string `json:"userId"`
+	Username   string `json:"username"`
+	Status     string `json:"status"`
+	StatusText string `json:"statusText"`
+}
+
+// SubscribeUserStatus calls handler with the status changes of all users.
+// handler is called from the read loop of the connection and must not block
+// on calls over the same connection. The returned func removes handler and
+// ends the subscription.
+func (r *RealtimeClient) SubscribeUserStatus(ctx context.Context, handler func(UserStatusEvent)) (func() error, error) {
+	remove := r.onStream("stream-notify-logged", "user-status", func(args []json.RawMessage) {
+		for _, arg := range args {
+			if event, ok := parseUserStatus(arg); ok {
+				handler(event)
+			}
+		}
+	})
+
+	id, err := r.subscribe(ctx, "stream-notify-logged", "user-status", false)
+	if err != nil {
+		remove()
+		return nil, err
+	}
+
+	return func() error {
+		remove()
+		return r.unsubscribe(id)
+	}, nil
+}
+
+// parseUserStatus parses the [userId, username, status, statusText] event.
+func parseUserStatus(arg json.RawMessage) (UserStatusEvent, bool) {
+	var v []interface{}
+	if err := json.Unmarshal(arg, &v); err != nil || len(v) < 3 {
+		return UserStatusEvent{}, false
+	}
+
+	event := UserStatusEvent{}
+	event.UserID, _ = v[0].(string)
+	event.Username, _ = v[1].(string)
+	switch s := v[2].(type) {
+	case float64:
+		if int(s) >= 0 && int(s) < len(userStatuses) {
+			event.Status = userStatuses[int(s)]
+		}
+	case string:
+		event.Status = s
+	}
+	if len(v) > 3 {
+		event.StatusText, _ = v[3].(string)
+	}
+
+	return event, event.UserID != "" && event.Status != ""
+}
+
+// PresenceCache holds the last known status of the users.
+type PresenceCache struct {
+	mu         sync.RWMutex
+	users      map[string]UserStatusEvent
+	byUsername map[string]string
+}
+
+// NewPresenceCache creates an empty presence cache.
+func NewPresenceCache() *PresenceCache {
+	return &PresenceCache{
+		users:      map[string]UserStatusEvent{},
+		byUsername: map[string]string{},
+	}
+}
+
+// Set records the status of a user.
+func (p *PresenceCache) Set(event UserStatusEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set(event)
+}
+
+func (p *PresenceCache) set(event UserStatusEvent) {
+	if prev, ok := p.users[event.UserID]; ok && prev.Username != event.Username {
+		delete(p.byUsername, prev.Username)
+	}
+	if event.Username == "" {
+		event.Username = p.users[event.UserID].Username
+	}
+
+	p.users[event.UserID] = event
+	if event.Username != "" {
+		p.byUsername[event.Username] = event.UserID
+	}
+}
+
+// Get returns the status of a user by id.
+func (p *PresenceCache) Get(userID string) (UserStatusEvent, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	event, ok := p.users[userID]
+	return event, ok
+}
+
+// GetByUsername returns the status of a user by username.
+func (p *PresenceCache) GetByUsername(username string) (UserStatusEvent, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	event, ok := p.users[p.byUsername[username]]
+	return event, ok
+}
+
+// List returns the status of all known users sorted by username. If statuses
+// are given, only the users with one of them are returned.
+func (p *PresenceCache) List(statuses ...string) []UserStatusEvent {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	list := []UserStatusEvent{}
+	for _, event := range p.users {
+		if len(statuses) == 0 || hasStatus(statuses, event.Status) {
+			list = append(list, event)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Username != list[j].Username {
+			return list[i].Username < list[j].Username
+		}
+		return list[i].UserID < list[j].UserID
+	})
+
+	return list
+}
+
+func hasStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WatchPresence keeps cache current with the user-status stream, and fills it
+// with the presence of the connected users. Statuses received from the
+// stream take precedence over the snapshot. The returned func stops updating
+// cache.
+func (r *RealtimeClient) WatchPresence(ctx context.Context, cache *PresenceCache) (func() error, error) {
+	unsubscribe, err := r.SubscribeUserStatus(ctx, cache.Set)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.UsersPresence("")
+	if err == nil && !res.Success {
+		err = fmt.Errorf("users presence failed")
+	}
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for _, u := range res.Users {
+		if _, ok := cache.users[u.ID]; !ok {
+			cache.set(UserStatusEvent{UserID: u.ID, Username: u.Username, Status: u.Status})
+		}
+	}
+
+	return unsubscribe, nil
+}
@@ -0,0 +1,105 @@
+package gorocket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeUserStatus(t *testing.T) {
+	unsub := make(chan map[string]interface{}, 1)
+	var subID interface{}
+	server := newDDPServer(t, nil, func(c *ddpConn, msg map[string]interface{}) {
+		if msg["msg"] == "unsub" {
+			unsub <- msg
+			return
+		}
+
+		require.Equal(t, "sub", msg["msg"])
+		require.Equal(t, "stream-notify-logged", msg["name"])
+		require.Equal(t, []interface{}{"user-status", false}, msg["params"])
+
+		subID = msg["id"]
+		c.send(map[string]interface{}{"msg": "ready", "subs": []interface{}{msg["id"]}})
+		c.event("stream-notify-logged", "user-status", []interface{}{"u2", "jane", 2, "In a meeting"})
+		c.event("stream-notify-logged", "user-status", []interface{}{"u3", "bob", 0})
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+	defer rt.Close()
+
+	events := make(chan UserStatusEvent, 2)
+	unsubscribe, err := rt.SubscribeUserStatus(context.Background(), func(e UserStatusEvent) {
+		events <- e
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, UserStatusEvent{UserID: "u2", Username: "jane", Status: UserStatusAway, StatusText: "In a meeting"}, <-events)
+	require.Equal(t, UserStatusEvent{UserID: "u3", Username: "bob", Status: UserStatusOffline}, <-events)
+
+	require.NoError(t, unsubscribe())
+	require.Equal(t, map[string]interface{}{"msg": "unsub", "id": subID}, <-unsub)
+	rt.mu.Lock()
+	require.Empty(t, rt.streams["stream-notify-logged/user-status"])
+	rt.mu.Unlock()
+}
+
+func TestPresenceCache(t *testing.T) {
+	cache := NewPresenceCache()
+	cache.Set(UserStatusEvent{UserID: "u1", Username: "john", Status: UserStatusOnline})
+	cache.Set(UserStatusEvent{UserID: "u2", Username: "jane", Status: UserStatusBusy})
+	cache.Set(UserStatusEvent{UserID: "u1", Username: "johnny", Status: UserStatusAway})
+
+	e, ok := cache.Get("u1")
+	require.True(t, ok)
+	require.Equal(t, UserStatusAway, e.Status)
+
+	_, ok = cache.GetByUsername("john")
+	require.False(t, ok)
+	e, ok = cache.GetByUsername("johnny")
+	require.True(t, ok)
+	require.Equal(t, "u1", e.UserID)
+
+	require.Equal(t, []UserStatusEvent{
+		{UserID: "u2", Username: "jane", Status: UserStatusBusy},
+		{UserID: "u1", Username: "johnny", Status: UserStatusAway},
+	}, cache.List())
+	require.Len(t, cache.List(UserStatusBusy), 1)
+}
+
+func TestWatchPresence(t *testing.T) {
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/users.presence", r.URL.Path)
+		w.Write([]byte(`{"users":[{"_id":"u2","username":"jane","status":"online"},{"_id":"u3","username":"bob","status":"away"}],"full":true,"success":true}`))
+	})
+
+	server := newDDPServer(t, rest, func(c *ddpConn, msg map[string]interface{}) {
+		if msg["msg"] != "sub" {
+			return
+		}
+		c.send(map[string]interface{}{"msg": "ready", "subs": []interface{}{msg["id"]}})
+		c.event("stream-notify-logged", "user-status", []interface{}{"u2", "jane", 3, ""})
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+	defer rt.Close()
+
+	cache := NewPresenceCache()
+	stop, err := rt.WatchPresence(context.Background(), cache)
+	require.NoError(t, err)
+	defer stop()
+
+	require.Eventually(t, func() bool {
+		e, _ := cache.GetByUsername("jane")
+		return e.Status == UserStatusBusy
+	}, time.Second, 10*time.Millisecond)
+
+	e, ok := cache.Get("u3")
+	require.True(t, ok)
+	require.Equal(t, UserStatusAway, e.Status)
+}
@@ -0,0 +1,380 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrRealtimeClosed is returned by the calls on a closed realtime connection.
+var ErrRealtimeClosed = errors.New("realtime connection closed")
+
+// RealtimeClient is a connection to the realtime API of the server, which
+// speaks the DDP protocol over a websocket.
+type RealtimeClient struct {
+	client *Client
+	conn   *websocket.Conn
+
+	// writeMu serializes the writes to conn
+	writeMu sync.Mutex
+
+	// mu guards pending, subs, streams and username
+	mu       sync.Mutex
+	pending  map[string]chan methodResult
+	subs     map[string]chan error
+	streams  map[string][]*streamHandler
+	username string
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+// streamHandler receives the arguments of the events of a stream.
+type streamHandler struct {
+	handle func(args []json.RawMessage)
+}
+
+type ddpMessage struct {
+	Msg        string          `json:"msg"`
+	ID         string          `json:"id,omitempty"`
+	Subs       []string        `json:"subs,omitempty"`
+	Collection string          `json:"collection,omitempty"`
+	Fields     json.RawMessage `json:"fields,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      *MethodError    `json:"error,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+}
+
+type ddpStreamFields struct {
+	EventName string            `json:"eventName"`
+	Args      []json.RawMessage `json:"args"`
+}
+
+// Realtime opens a realtime connection and logs in with the credentials of
+// the client. The connection is closed when ctx is done before it is ready.
+func (c *Client) Realtime(ctx context.Context) (*RealtimeClient, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RealtimeClient{
+		client:  c,
+		conn:    conn,
+		pending: map[string]chan methodResult{},
+		subs:    map[string]chan error{},
+		streams: map[string][]*streamHandler{},
+		done:    make(chan struct{}),
+	}
+
+	if err := r.handshake(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go r.readLoop()
+
+	creds, err := c.requestCredentials(ctx)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	if err := r.call(ctx, "login", nil, map[string]string{"resume": creds.AuthToken}); err != nil {
+		r.Close()
+		return nil, fmt.Errorf("realtime login: %w", err)
+	}
+
+	return r, nil
+}
+
+// Close closes the connection. Pending calls fail with ErrRealtimeClosed.
+func (r *RealtimeClient) Close() error {
+	r.shutdown(ErrRealtimeClosed)
+	return r.conn.Close()
+}
+
+// Done is closed when the connection is closed.
+func (r *RealtimeClient) Done() <-chan struct{} {
+	return r.done
+}
+
+// Err returns why the connection was closed, nil while it is open.
+func (r *RealtimeClient) Err() error {
+	select {
+	case <-r.done:
+		return r.err
+	default:
+		return nil
+	}
+}
+
+// CallMethod calls a server method over the realtime connection. A failed
+// method returns a *MethodError.
+func (r *RealtimeClient) CallMethod(ctx context.Context, method string, params ...interface{}) (MethodResult, error) {
+	if method == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	var result json.RawMessage
+	if err := r.call(ctx, method, &result, params...); err != nil {
+		return nil, err
+	}
+
+	return MethodResult(result), nil
+}
+
+func (r *RealtimeClient) handshake(ctx context.Context) error {
+	// closing conn unblocks the reads when ctx is done
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.conn.Close()
+		case <-finished:
+		}
+	}()
+
+	if err := r.send(map[string]interface{}{"msg": "connect", "version": "1", "support": []string{"1"}}); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	for {
+		msg := ddpMessage{}
+		if err := r.conn.ReadJSON(&msg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		switch msg.Msg {
+		case "connected":
+			return nil
+		case "failed":
+			return fmt.Errorf("realtime connect failed")
+		}
+	}
+}
+
+func (r *RealtimeClient) readLoop() {
+	for {
+		msg := ddpMessage{}
+		if err := r.conn.ReadJSON(&msg); err != nil {
+			r.shutdown(err)
+			return
+		}
+
+		switch msg.Msg {
+		case "ping":
+			pong := map[string]string{"msg": "pong"}
+			if msg.ID != "" {
+				pong["id"] = msg.ID
+			}
+			r.send(pong)
+		case "result":
+			r.mu.Lock()
+			ch := r.pending[msg.ID]
+			delete(r.pending, msg.ID)
+			r.mu.Unlock()
+
+			if ch != nil {
+				ch <- methodResult{Msg: msg.Msg, ID: msg.ID, Result: msg.Result, Error: msg.Error}
+			}
+		case "ready":
+			for _, id := range msg.Subs {
+				r.subscribed(id, nil)
+			}
+		case "nosub":
+			err := fmt.Errorf("subscription refused")
+			if msg.Error != nil {
+				err = msg.Error
+			}
+			r.subscribed(msg.ID, err)
+		case "changed":
+			r.dispatch(msg)
+		case "error":
+			r.shutdown(fmt.Errorf("realtime protocol error: %s", msg.Reason))
+			r.conn.Close()
+			return
+		}
+	}
+}
+
+func (r *RealtimeClient) subscribed(id string, err error) {
+	r.mu.Lock()
+	ch := r.subs[id]
+	delete(r.subs, id)
+	r.mu.Unlock()
+
+	if ch != nil {
+		ch <- err
+	}
+}
+
+func (r *RealtimeClient) dispatch(msg ddpMessage) {
+	fields := ddpStreamFields{}
+	if err := json.Unmarshal(msg.Fields, &fields); err != nil || fields.EventName == "" {
+		return
+	}
+
+	r.mu.Lock()
+	handlers := append([]*streamHandler(nil), r.streams[streamKey(msg.Collection, fields.EventName)]...)
+	r.mu.Unlock()
+
+	for _, h := range handlers {
+		h.handle(fields.Args)
+	}
+}
+
+func (r *RealtimeClient) shutdown(err error) {
+	r.closeOnce.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
+
+func (r *RealtimeClient) send(v interface{}) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	return r.conn.WriteJSON(v)
+}
+
+// call calls a server method and decodes its result into result, if not nil.
+func (r *RealtimeClient) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	id, err := randomID()
+	if err != nil {
+		return err
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	ch := make(chan methodResult, 1)
+	r.mu.Lock()
+	r.pending[id] = ch
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, id)
+		r.mu.Unlock()
+	}()
+
+	if err := r.send(methodCall{Msg: "method", ID: id, Method: method, Params: params}); err != nil {
+		if closed := r.Err(); closed != nil {
+			return closed
+		}
+		return err
+	}
+
+	select {
+	case res := <-ch:
+		return res.decode(result)
+	case <-r.done:
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// subscribe subscribes to a publication and waits until it is ready. The
+// events of stream publications are passed to the stream handlers.
+func (r *RealtimeClient) subscribe(ctx context.Context, name string, params ...interface{}) (string, error) {
+	id, err := randomID()
+	if err != nil {
+		return "", err
+	}
+
+	ch := make(chan error, 1)
+	r.mu.Lock()
+	r.subs[id] = ch
+	r.mu.Unlock()
+
+	sub := map[string]interface{}{"msg": "sub", "id": id, "name": name, "params": params}
+	if err := r.send(sub); err != nil {
+		r.mu.Lock()
+		delete(r.subs, id)
+		r.mu.Unlock()
+		if closed := r.Err(); closed != nil {
+			return "", closed
+		}
+		return "", err
+	}
+
+	select {
+	case err := <-ch:
+		if err != nil {
+			return "", fmt.Errorf("subscribe %s: %w", name, err)
+		}
+		return id, nil
+	case <-r.done:
+		return "", r.err
+	case <-ctx.Done():
+		r.mu.Lock()
+		delete(r.subs, id)
+		r.mu.Unlock()
+		return "", ctx.Err()
+	}
+}
+
+// unsubscribe stops a subscription made with subscribe.
+func (r *RealtimeClient) unsubscribe(id string) error {
+	if err := r.send(map[string]interface{}{"msg": "unsub", "id": id}); err != nil {
+		if closed := r.Err(); closed != nil {
+			return closed
+		}
+		return err
+	}
+
+	return nil
+}
+
+// onStream calls handle with the arguments of each eventName event of the
+// stream, from the read loop. It returns a func removing the handler.
+func (r *RealtimeClient) onStream(stream, eventName string, handle func(args []json.RawMessage)) func() {
+	key := streamKey(stream, eventName)
+	h := &streamHandler{handle: handle}
+
+	r.mu.Lock()
+	r.streams[key] = append(r.streams[key], h)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		handlers := r.streams[key]
+		for i := range handlers {
+			if handlers[i] == h {
+				r.streams[key] = append(handlers[:i:i], handlers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func streamKey(stream, eventName string) string {
+	return stream + "/" + eventName
+}
@@ -0,0 +1,194 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// ddpConn is the server side of a realtime connection in tests.
+type ddpConn struct {
+	t    *testing.T
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (c *ddpConn) send(v interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	require.NoError(c.t, c.conn.WriteJSON(v))
+}
+
+func (c *ddpConn) result(id string, result interface{}) {
+	c.send(map[string]interface{}{"msg": "result", "id": id, "result": result})
+}
+
+func (c *ddpConn) event(stream, eventName string, args ...interface{}) {
+	c.send(map[string]interface{}{
+		"msg":        "changed",
+		"collection": stream,
+		"id":         "id",
+		"fields":     map[string]interface{}{"eventName": eventName, "args": args},
+	})
+}
+
+// newDDPServer serves the realtime API on /websocket and rest on the other
+// paths. It answers connect and login, and passes the other messages to handle.
+func newDDPServer(t *testing.T, rest http.Handler, handle func(c *ddpConn, msg map[string]interface{})) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+
+	mux := http.NewServeMux()
+	if rest != nil {
+		mux.Handle("/", rest)
+	}
+	mux.HandleFunc("/websocket", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		c := &ddpConn{t: t, conn: ws}
+		for {
+			msg := map[string]interface{}{}
+			if err := ws.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			switch {
+			case msg["msg"] == "connect":
+				c.send(map[string]string{"msg": "connected", "session": "s1"})
+			case msg["msg"] == "method" && msg["method"] == "login":
+				params := msg["params"].([]interface{})
+				require.Equal(t, map[string]interface{}{"resume": "token"}, params[0])
+				c.result(msg["id"].(string), map[string]string{"id": "u1", "token": "token"})
+			default:
+				handle(c, msg)
+			}
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestRealtimeClient(t *testing.T, server *httptest.Server) (*Client, *RealtimeClient) {
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rt, err := client.Realtime(ctx)
+	require.NoError(t, err)
+
+	return client, rt
+}
+
+func TestRealtime(t *testing.T) {
+	pong := make(chan map[string]interface{}, 1)
+	server := newDDPServer(t, nil, func(c *ddpConn, msg map[string]interface{}) {
+		switch msg["msg"] {
+		case "pong":
+			pong <- msg
+		case "method":
+			require.Equal(t, "getServerInfo", msg["method"])
+			c.send(map[string]interface{}{"msg": "ping", "id": "p1"})
+			c.result(msg["id"].(string), map[string]string{"version": "6.0.0"})
+		}
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+
+	info := map[string]string{}
+	require.NoError(t, rt.call(context.Background(), "getServerInfo", &info))
+	require.Equal(t, "6.0.0", info["version"])
+	require.Equal(t, map[string]interface{}{"msg": "pong", "id": "p1"}, <-pong)
+
+	require.NoError(t, rt.Err())
+	require.NoError(t, rt.Close())
+	<-rt.Done()
+	require.Equal(t, ErrRealtimeClosed, rt.Err())
+	require.Equal(t, ErrRealtimeClosed, rt.call(context.Background(), "getServerInfo", nil))
+}
+
+func TestRealtimeLoginFailed(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		for {
+			msg := map[string]interface{}{}
+			if err := ws.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg["msg"] == "connect" {
+				ws.WriteJSON(map[string]string{"msg": "connected"})
+			} else {
+				ws.WriteJSON(map[string]interface{}{"msg": "result", "id": msg["id"], "error": map[string]interface{}{"error": 403, "reason": "You've been logged out by the server. Please log in again."}})
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("expired"))
+	_, err := client.Realtime(context.Background())
+	require.EqualError(t, err, "realtime login: You've been logged out by the server. Please log in again. [403]")
+}
+
+func TestRealtimeStreams(t *testing.T) {
+	server := newDDPServer(t, nil, func(c *ddpConn, msg map[string]interface{}) {
+		require.Equal(t, "sub", msg["msg"])
+		c.send(map[string]interface{}{"msg": "ready", "subs": []interface{}{msg["id"]}})
+		c.event("stream-room-messages", "GENERAL", map[string]string{"msg": "hello"})
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+	defer rt.Close()
+
+	events := make(chan []json.RawMessage, 2)
+	remove := rt.onStream("stream-room-messages", "GENERAL", func(args []json.RawMessage) {
+		events <- args
+	})
+
+	_, err := rt.subscribe(context.Background(), "stream-room-messages", "GENERAL", false)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"msg":"hello"}`, string((<-events)[0]))
+
+	remove()
+	rt.mu.Lock()
+	require.Empty(t, rt.streams["stream-room-messages/GENERAL"])
+	rt.mu.Unlock()
+}
+
+func TestRealtimeHandshakeCanceled(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		// never answers connect
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	client := NewWithOptions(server.URL, WithUserID("u1"), WithXToken("token"))
+	_, err := client.Realtime(ctx)
+	require.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,227 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	RoleScopeUsers         = "Users"
+	RoleScopeSubscriptions = "Subscriptions"
+)
+
+type Role struct {
+	ID           string    `json:"_id"`
+	Name         string    `json:"name"`
+	Scope        string    `json:"scope"`
+	Description  string    `json:"description"`
+	Protected    bool      `json:"protected"`
+	Mandatory2fa bool      `json:"mandatory2fa"`
+	UpdatedAt    time.Time `json:"_updatedAt"`
+}
+
+type RolesListResponse struct {
+	Roles   []Role `json:"roles"`
+	Success bool   `json:"success"`
+}
+
+type RoleRequest struct {
+	Name         string `json:"name"`
+	Scope        string `json:"scope,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Mandatory2fa bool   `json:"mandatory2fa,omitempty"`
+}
+
+type UpdateRoleRequest struct {
+	RoleId       string `json:"roleId"`
+	Name         string `json:"name"`
+	Scope        string `json:"scope,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Mandatory2fa bool   `json:"mandatory2fa,omitempty"`
+}
+
+type RoleResponse struct {
+	Role    Role `json:"role"`
+	Success bool `json:"success"`
+}
+
+type RoleUserRequest struct {
+	RoleId   string `json:"roleId"`
+	Username string `json:"username"`
+	// RoomId is required for roles with the Subscriptions scope
+	RoomId string `json:"roomId,omitempty"`
+}
+
+type UsersInRoleRequest struct {
+	Role   string
+	RoomId string
+}
+
+type UsersInRoleResponse struct {
+	Users   []SingleUserInfo `json:"users"`
+	Total   int              `json:"total"`
+	Success bool             `json:"success"`
+}
+
+// RolesList gets all the roles in the system.
+func (c *Client) RolesList() (*RolesListResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/roles.list", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := RolesListResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesCreate creates a new role.
+func (c *Client) RolesCreate(param *RoleRequest) (*RoleResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/roles.create", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := RoleResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesUpdate updates a role.
+func (c *Client) RolesUpdate(param *UpdateRoleRequest) (*RoleResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/roles.update", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := RoleResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesDelete deletes a role.
+func (c *Client) RolesDelete(roleId string) (*SimpleSuccessResponse, error) {
+	param := struct {
+		RoleId string `json:"roleId"`
+	}{
+		RoleId: roleId,
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/roles.delete", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesAddUserToRole assigns a role to a user.
+func (c *Client) RolesAddUserToRole(param *RoleUserRequest) (*RoleResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/roles.addUserToRole", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := RoleResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesRemoveUserFromRole removes a role from a user.
+func (c *Client) RolesRemoveUserFromRole(param *RoleUserRequest) (*RoleResponse, error) {
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/roles.removeUserFromRole", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := RoleResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RolesGetUsersInRole gets the users that belong to a role.
+func (c *Client) RolesGetUsersInRole(param *UsersInRoleRequest) (*UsersInRoleResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/roles.getUsersInRole", c.baseURL, c.apiVersion),
+		nil)
+
+	if param.Role == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	url := req.URL.Query()
+	url.Add("role", param.Role)
+	if param.RoomId != "" {
+		url.Add("roomId", param.RoomId)
+	}
+	req.URL.RawQuery = url.Encode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := UsersInRoleResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,124 @@
+package gorocket
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesList(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"roles":[{"_id":"admin","name":"admin","scope":"Users","description":"Admin","protected":true,"mandatory2fa":false,"_updatedAt":"2023-10-10T23:40:46.093Z"},{"_id":"owner","name":"owner","scope":"Subscriptions","description":"Owner","protected":true,"mandatory2fa":false,"_updatedAt":"2023-10-10T23:40:46.093Z"}],"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesList()
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(resp.Roles))
+	require.Equal(t, "admin", resp.Roles[0].ID)
+	require.Equal(t, RoleScopeUsers, resp.Roles[0].Scope)
+	require.True(t, resp.Roles[0].Protected)
+	require.Equal(t, RoleScopeSubscriptions, resp.Roles[1].Scope)
+	require.Equal(t, "2023-10-10T23:40:46.093Z", resp.Roles[1].UpdatedAt.Format("2006-01-02T15:04:05.999Z"))
+	require.True(t, resp.Success)
+}
+
+func TestRolesCreate(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"role":{"_id":"6fZ8eAqFmBrPSy4bD","name":"auditor","scope":"Users","description":"Read-only","protected":false,"mandatory2fa":true},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesCreate(&RoleRequest{
+		Name:         "auditor",
+		Scope:        RoleScopeUsers,
+		Description:  "Read-only",
+		Mandatory2fa: true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "6fZ8eAqFmBrPSy4bD", resp.Role.ID)
+	require.Equal(t, "auditor", resp.Role.Name)
+	require.True(t, resp.Role.Mandatory2fa)
+	require.True(t, resp.Success)
+}
+
+func TestRolesUpdate(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"role":{"_id":"6fZ8eAqFmBrPSy4bD","name":"auditor","scope":"Users","description":"Audit team","protected":false,"mandatory2fa":true},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesUpdate(&UpdateRoleRequest{
+		RoleId:      "6fZ8eAqFmBrPSy4bD",
+		Name:        "auditor",
+		Description: "Audit team",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "Audit team", resp.Role.Description)
+	require.True(t, resp.Success)
+}
+
+func TestRolesDelete(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesDelete("6fZ8eAqFmBrPSy4bD")
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestRolesAddUserToRole(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"role":{"_id":"auditor","name":"auditor","scope":"Users","description":"","protected":false},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesAddUserToRole(&RoleUserRequest{RoleId: "auditor", Username: "john"})
+	require.NoError(t, err)
+
+	require.Equal(t, "auditor", resp.Role.ID)
+	require.True(t, resp.Success)
+}
+
+func TestRolesRemoveUserFromRole(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"role":{"_id":"auditor","name":"auditor","scope":"Users","description":"","protected":false},"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesRemoveUserFromRole(&RoleUserRequest{RoleId: "auditor", Username: "john"})
+	require.NoError(t, err)
+
+	require.Equal(t, "auditor", resp.Role.ID)
+	require.True(t, resp.Success)
+}
+
+func TestRolesGetUsersInRole(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"users":[{"_id":"5fRTXMt7DMJbpPJfh","username":"test.john","type":"user","status":"offline","active":true,"name":"Test John"}],"total":1,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RolesGetUsersInRole(&UsersInRoleRequest{Role: "admin"})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "test.john", resp.Users[0].Username)
+	require.True(t, resp.Success)
+
+	_, err = client.RolesGetUsersInRole(&UsersInRoleRequest{})
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package gorocket
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	RoomTypeChannel  = "c"
+	RoomTypeGroup    = "p"
+	RoomTypeDirect   = "d"
+	RoomTypeLivechat = "l"
+)
+
+type Room struct {
+	ID           string       `json:"_id"`
+	Type         string       `json:"t"`
+	Name         string       `json:"name,omitempty"`
+	FName        string       `json:"fname,omitempty"`
+	Usernames    []string     `json:"usernames,omitempty"`
+	U            UChat        `json:"u,omitempty"`
+	Topic        string       `json:"topic,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	ReadOnly     bool         `json:"ro,omitempty"`
+	Archived     bool         `json:"archived,omitempty"`
+	Default      bool         `json:"default,omitempty"`
+	Msgs         int          `json:"msgs"`
+	UsersCount   int          `json:"usersCount"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
+	Ts           time.Time    `json:"ts"`
+	UpdatedAt    time.Time    `json:"_updatedAt"`
+}
+
+type RoomsGetResponse struct {
+	Update  []Room `json:"update"`
+	Remove  []Room `json:"remove"`
+	Success bool   `json:"success"`
+}
+
+// RoomsGet lists the rooms the user belongs to. If updatedSince is set, only
+// the rooms changed and removed since then are returned.
+func (c *Client) RoomsGet(updatedSince time.Time) (*RoomsGetResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/rooms.get", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !updatedSince.IsZero() {
+		url := req.URL.Query()
+		url.Add("updatedSince", updatedSince.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := RoomsGetResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,29 @@
+package gorocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/rooms.get", r.URL.Path)
+		require.Equal(t, "2018-03-01T18:00:00Z", r.URL.Query().Get("updatedSince"))
+
+		w.Write([]byte(`{"update":[{"_id":"GENERAL","t":"c","name":"general","msgs":12,"usersCount":3,"default":true,"ts":"2018-01-21T21:04:34.591Z","_updatedAt":"2018-03-01T18:02:26.828Z"},{"_id":"rid1rid2","t":"d","usernames":["john","rocket.cat"],"msgs":1,"ts":"2018-01-21T21:04:34.591Z","_updatedAt":"2018-03-01T18:02:26.828Z"}],"remove":[],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.RoomsGet(time.Date(2018, 3, 1, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, 2, len(resp.Update))
+	require.Equal(t, RoomTypeChannel, resp.Update[0].Type)
+	require.True(t, resp.Update[0].Default)
+	require.Equal(t, []string{"john", "rocket.cat"}, resp.Update[1].Usernames)
+}
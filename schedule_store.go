@@ -0,0 +1,154 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// ScheduleStore keeps the queue of a Scheduler.
+type ScheduleStore interface {
+	// Save adds a message or replaces the message with the same id
+	Save(msg ScheduledMessage) error
+	// Delete removes a message, it is not an error if it does not exist
+	Delete(id string) error
+	List() ([]ScheduledMessage, error)
+}
+
+// MemoryScheduleStore keeps the queue in memory, it is lost on restart.
+type MemoryScheduleStore struct {
+	mu   sync.Mutex
+	msgs map[string]ScheduledMessage
+}
+
+// NewMemoryScheduleStore creates an empty in-memory store.
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{msgs: map[string]ScheduledMessage{}}
+}
+
+func (m *MemoryScheduleStore) Save(msg ScheduledMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.msgs[msg.ID] = msg
+	return nil
+}
+
+func (m *MemoryScheduleStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.msgs, id)
+	return nil
+}
+
+func (m *MemoryScheduleStore) List() ([]ScheduledMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	msgs := make([]ScheduledMessage, 0, len(m.msgs))
+	for _, msg := range m.msgs {
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// FileScheduleStore keeps the queue in a JSON file. The file is rewritten on
+// every change, so the queue survives a restart.
+type FileScheduleStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileScheduleStore creates a store in the file at path. The file is
+// created on the first change if it does not exist.
+func NewFileScheduleStore(path string) *FileScheduleStore {
+	return &FileScheduleStore{path: path}
+}
+
+func (f *FileScheduleStore) Save(msg ScheduledMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	msgs, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range msgs {
+		if msgs[i].ID == msg.ID {
+			msgs[i] = msg
+			replaced = true
+		}
+	}
+	if !replaced {
+		msgs = append(msgs, msg)
+	}
+
+	return f.write(msgs)
+}
+
+func (f *FileScheduleStore) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	msgs, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	kept := msgs[:0]
+	for _, msg := range msgs {
+		if msg.ID != id {
+			kept = append(kept, msg)
+		}
+	}
+	if len(kept) == len(msgs) {
+		return nil
+	}
+
+	return f.write(kept)
+}
+
+func (f *FileScheduleStore) List() ([]ScheduledMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.read()
+}
+
+func (f *FileScheduleStore) read() ([]ScheduledMessage, error) {
+	b, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []ScheduledMessage{}
+	if err := json.Unmarshal(b, &msgs); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// write replaces the file through a temporary file, so a crash never leaves
+// a half written queue behind.
+func (f *FileScheduleStore) write(msgs []ScheduledMessage) error {
+	b, err := json.MarshalIndent(msgs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, f.path)
+}
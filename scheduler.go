@@ -0,0 +1,276 @@
+package gorocket
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduledMessage is a message waiting in the queue of a Scheduler.
+type ScheduledMessage struct {
+	ID      string  `json:"id"`
+	Message Message `json:"message"`
+	// At is the next time the message is sent
+	At time.Time `json:"at"`
+	// Cron is the cron expression of a repeated message, empty for a one-off message
+	Cron string `json:"cron,omitempty"`
+	// Attempts is the number of failed attempts to send the message at At
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Scheduler posts messages at a given time or on a cron schedule. The queue
+// is kept in a ScheduleStore, so messages survive a restart when the store is
+// persistent. Messages due while the scheduler was not running are sent as
+// soon as Run starts.
+type Scheduler struct {
+	client *Client
+	store  ScheduleStore
+
+	// MaxAttempts is the number of attempts to send a message, 5 if not set.
+	// A one-off message is dropped after the last attempt, a repeated message
+	// is sent again on its next schedule.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry, doubled on every
+	// following retry, 30 seconds if not set
+	RetryDelay time.Duration
+	// OnError is called when a message could not be sent after MaxAttempts
+	OnError func(msg ScheduledMessage, err error)
+
+	// mu makes Cancel and the updates of a message being sent exclusive, so
+	// a message cancelled while it is sent is not saved again
+	mu sync.Mutex
+
+	wake chan struct{}
+	now  func() time.Time
+}
+
+// NewScheduler creates a scheduler posting messages with client.
+func NewScheduler(client *Client, store ScheduleStore) *Scheduler {
+	return &Scheduler{
+		client: client,
+		store:  store,
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// ScheduleAt queues msg to be posted at the given time and returns its id.
+func (s *Scheduler) ScheduleAt(msg *Message, at time.Time) (string, error) {
+	return s.schedule(ScheduledMessage{Message: *msg, At: at})
+}
+
+// ScheduleIn queues msg to be posted after the given delay and returns its id.
+func (s *Scheduler) ScheduleIn(msg *Message, delay time.Duration) (string, error) {
+	return s.ScheduleAt(msg, s.now().Add(delay))
+}
+
+// ScheduleCron queues msg to be posted on a cron schedule and returns its id.
+// spec is a standard five field expression like "30 9 * * MON-FRI" or a
+// descriptor like "@daily", prefixed by "CRON_TZ=Europe/Berlin " to use
+// another time zone than the local one.
+func (s *Scheduler) ScheduleCron(msg *Message, spec string) (string, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return "", err
+	}
+
+	return s.schedule(ScheduledMessage{Message: *msg, At: schedule.Next(s.now()), Cron: spec})
+}
+
+// Cancel removes a message from the queue.
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	err := s.store.Delete(id)
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	s.notify()
+	return nil
+}
+
+// List returns the queued messages, the next one first.
+func (s *Scheduler) List() ([]ScheduledMessage, error) {
+	msgs, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].At.Before(msgs[j].At)
+	})
+
+	return msgs, nil
+}
+
+// Run posts the queued messages when they are due until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msgs, err := s.List()
+		if err != nil {
+			return err
+		}
+
+		now := s.now()
+		wait := time.Duration(-1)
+		sent := false
+		for _, msg := range msgs {
+			if msg.At.After(now) {
+				wait = msg.At.Sub(now)
+				break
+			}
+
+			if err := s.send(msg); err != nil {
+				return err
+			}
+			sent = true
+		}
+
+		if sent {
+			// retries and repeated messages changed the queue
+			continue
+		}
+
+		var timer *time.Timer
+		var due <-chan time.Time
+		if wait >= 0 {
+			timer = time.NewTimer(wait)
+			due = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-due:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// send posts a due message and updates the queue. Only errors of the store
+// are returned, failed messages are retried.
+func (s *Scheduler) send(msg ScheduledMessage) error {
+	sendErr := s.post(&msg.Message)
+	if sendErr == nil {
+		return s.next(msg)
+	}
+
+	msg.Attempts++
+	msg.LastError = sendErr.Error()
+
+	if msg.Attempts >= s.maxAttempts() {
+		if s.OnError != nil {
+			s.OnError(msg, sendErr)
+		}
+		return s.next(msg)
+	}
+
+	msg.At = s.now().Add(s.retryDelay() << uint(msg.Attempts-1))
+	return s.update(msg)
+}
+
+func (s *Scheduler) post(msg *Message) error {
+	res, err := s.client.PostMessage(msg)
+	if err != nil {
+		return err
+	}
+	if !res.Success {
+		return fmt.Errorf("message not posted: %s", res.Error)
+	}
+
+	return nil
+}
+
+// next removes a one-off message from the queue, or moves a repeated message
+// to its next schedule.
+func (s *Scheduler) next(msg ScheduledMessage) error {
+	if msg.Cron == "" {
+		return s.store.Delete(msg.ID)
+	}
+
+	schedule, err := cron.ParseStandard(msg.Cron)
+	if err != nil {
+		return s.store.Delete(msg.ID)
+	}
+
+	msg.At = schedule.Next(s.now())
+	msg.Attempts = 0
+	msg.LastError = ""
+
+	return s.update(msg)
+}
+
+// update saves a message taken from the queue, unless it was cancelled in
+// the meantime.
+func (s *Scheduler) update(msg ScheduledMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msgs, err := s.store.List()
+	if err != nil {
+		return err
+	}
+
+	for _, queued := range msgs {
+		if queued.ID == msg.ID {
+			return s.store.Save(msg)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) schedule(msg ScheduledMessage) (string, error) {
+	if err := msg.Message.Validate(); err != nil {
+		return "", err
+	}
+
+	id, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	msg.ID = id
+
+	if err := s.store.Save(msg); err != nil {
+		return "", err
+	}
+
+	s.notify()
+	return id, nil
+}
+
+// notify wakes up Run to look at the changed queue.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) maxAttempts() int {
+	if s.MaxAttempts <= 0 {
+		return 5
+	}
+	return s.MaxAttempts
+}
+
+func (s *Scheduler) retryDelay() time.Duration {
+	if s.RetryDelay <= 0 {
+		return 30 * time.Second
+	}
+	return s.RetryDelay
+}
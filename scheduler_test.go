@@ -0,0 +1,227 @@
+package gorocket
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// postRecorder answers chat.postMessage, failing the first failures requests.
+type postRecorder struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	texts    []string
+}
+
+func (p *postRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	msg := Message{}
+	_ = json.NewDecoder(r.Body).Decode(&msg)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests++
+	if p.requests <= p.failures {
+		w.Write([]byte(`{"success":false,"error":"error-action-not-allowed"}`))
+		return
+	}
+	p.texts = append(p.texts, msg.Text)
+	w.Write([]byte(`{"success":true}`))
+}
+
+func (p *postRecorder) sent() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.texts...)
+}
+
+func runScheduler(t *testing.T, s *Scheduler) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		require.Equal(t, context.Canceled, <-done)
+	}
+}
+
+func TestSchedulerScheduleAt(t *testing.T) {
+	recorder := &postRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	scheduler := NewScheduler(client, NewMemoryScheduleStore())
+	stop := runScheduler(t, scheduler)
+	defer stop()
+
+	_, err := scheduler.ScheduleIn(&Message{RoomID: "GENERAL", Text: "later"}, 50*time.Millisecond)
+	require.NoError(t, err)
+	_, err = scheduler.ScheduleAt(&Message{RoomID: "GENERAL", Text: "overdue"}, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	id, err := scheduler.ScheduleIn(&Message{RoomID: "GENERAL", Text: "cancelled"}, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Cancel(id))
+
+	require.Eventually(t, func() bool { return len(recorder.sent()) == 2 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []string{"overdue", "later"}, recorder.sent())
+
+	msgs, err := scheduler.List()
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+}
+
+func TestSchedulerRetry(t *testing.T) {
+	recorder := &postRecorder{failures: 2}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	scheduler := NewScheduler(client, NewMemoryScheduleStore())
+	scheduler.MaxAttempts = 3
+	scheduler.RetryDelay = 5 * time.Millisecond
+	stop := runScheduler(t, scheduler)
+	defer stop()
+
+	_, err := scheduler.ScheduleIn(&Message{RoomID: "GENERAL", Text: "retried"}, 0)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(recorder.sent()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, 3, recorder.requests)
+}
+
+func TestSchedulerGivesUp(t *testing.T) {
+	recorder := &postRecorder{failures: 100}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	scheduler := NewScheduler(client, NewMemoryScheduleStore())
+	scheduler.MaxAttempts = 2
+	scheduler.RetryDelay = time.Millisecond
+
+	failed := make(chan ScheduledMessage, 1)
+	scheduler.OnError = func(msg ScheduledMessage, err error) {
+		require.EqualError(t, err, "message not posted: error-action-not-allowed")
+		failed <- msg
+	}
+	stop := runScheduler(t, scheduler)
+	defer stop()
+
+	_, err := scheduler.ScheduleIn(&Message{RoomID: "GENERAL", Text: "lost"}, 0)
+	require.NoError(t, err)
+
+	select {
+	case msg := <-failed:
+		require.Equal(t, 2, msg.Attempts)
+		require.Equal(t, "lost", msg.Message.Text)
+	case <-time.After(time.Second):
+		t.Fatal("OnError not called")
+	}
+
+	msgs, err := scheduler.List()
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+}
+
+func TestSchedulerCancelWhileSending(t *testing.T) {
+	for _, response := range []string{`{"success":true}`, `{"success":false}`} {
+		var scheduler *Scheduler
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, scheduler.Cancel("s1"))
+			w.Write([]byte(response))
+		}))
+
+		store := NewMemoryScheduleStore()
+		scheduler = NewScheduler(NewTestClientWithCustomHandler(t, server), store)
+		require.NoError(t, store.Save(ScheduledMessage{
+			ID:      "s1",
+			Message: Message{RoomID: "GENERAL", Text: "daily"},
+			At:      time.Now().Add(-time.Minute),
+			Cron:    "@daily",
+		}))
+
+		msgs, err := scheduler.List()
+		require.NoError(t, err)
+		require.NoError(t, scheduler.send(msgs[0]))
+
+		msgs, err = scheduler.List()
+		require.NoError(t, err)
+		require.Empty(t, msgs, response)
+
+		server.Close()
+	}
+}
+
+func TestSchedulerScheduleCron(t *testing.T) {
+	scheduler := NewScheduler(NewClient("http://localhost"), NewMemoryScheduleStore())
+	scheduler.now = func() time.Time {
+		return time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC) // Friday
+	}
+
+	_, err := scheduler.ScheduleCron(&Message{RoomID: "GENERAL", Text: "stand-up"}, "CRON_TZ=UTC 30 9 * * MON-FRI")
+	require.NoError(t, err)
+
+	msgs, err := scheduler.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(msgs))
+	require.Equal(t, time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC), msgs[0].At.UTC())
+
+	// sent once, then moved to the next schedule
+	require.NoError(t, scheduler.next(msgs[0]))
+	msgs, err = scheduler.List()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC), msgs[0].At.UTC())
+
+	_, err = scheduler.ScheduleCron(&Message{Text: "never"}, "every day")
+	require.Error(t, err)
+}
+
+func TestFileScheduleStoreRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "queue.json")
+
+	first := NewScheduler(NewClient("http://localhost"), NewFileScheduleStore(path))
+	_, err = first.ScheduleAt(&Message{
+		RoomID: "GENERAL",
+		Text:   "after restart",
+		Blocks: NewBlocks().Section(NewMarkdownText("*hi*")).Build(),
+	}, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	id, err := first.ScheduleIn(&Message{RoomID: "GENERAL", Text: "cancelled"}, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, first.Cancel(id))
+
+	recorder := &postRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	second := NewScheduler(NewTestClientWithCustomHandler(t, server), NewFileScheduleStore(path))
+	msgs, err := second.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(msgs))
+	require.Equal(t, "*hi*", msgs[0].Message.Blocks[0].(*SectionBlock).Text.Text)
+
+	stop := runScheduler(t, second)
+	defer stop()
+
+	require.Eventually(t, func() bool { return len(recorder.sent()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []string{"after restart"}, recorder.sent())
+}
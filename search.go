@@ -0,0 +1,124 @@
+package gorocket
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SearchOptions configures SearchAllRooms.
+type SearchOptions struct {
+	// Count is the maximum number of messages per room, 20 if not set
+	Count int
+	// Concurrency is the number of rooms searched at the same time, 4 if not set
+	Concurrency int
+	// RoomTypes limits the search to rooms of the given RoomType, all rooms if empty
+	RoomTypes []string
+}
+
+// SearchResult is a message found by SearchAllRooms and the room it was posted in.
+type SearchResult struct {
+	Message RoomMessage
+	Room    Room
+}
+
+// SearchAllRooms searches the messages of all rooms the user belongs to and
+// returns them newest first. The search stops at the first failing room.
+func (c *Client) SearchAllRooms(searchText string, opts *SearchOptions) ([]SearchResult, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	count := opts.Count
+	if count <= 0 {
+		count = 20
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	rooms, err := c.RoomsGet(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  []SearchResult
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for _, room := range rooms.Update {
+		if !hasRoomType(opts.RoomTypes, room.Type) {
+			continue
+		}
+
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(room Room) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			res, err := c.ChatSearch(&ChatSearchRequest{
+				RoomID:     room.ID,
+				SearchText: searchText,
+				Count:      count,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err == nil && !res.Success {
+				err = fmt.Errorf("search room %s failed: %s", room.ID, res.Error)
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, msg := range res.Messages {
+				results = append(results, SearchResult{Message: msg, Room: room})
+			}
+		}(room)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Message.Ts.Equal(results[j].Message.Ts) {
+			return results[i].Message.ID < results[j].Message.ID
+		}
+		return results[i].Message.Ts.After(results[j].Message.Ts)
+	})
+
+	return results, nil
+}
+
+func hasRoomType(types []string, t string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package gorocket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchAllRooms(t *testing.T) {
+	var (
+		mu            sync.Mutex
+		running, peak int
+		searched      []string
+	)
+
+	messages := map[string]string{
+		"GENERAL": `{"_id":"m1","rid":"GENERAL","msg":"old deploy","ts":"2018-03-01T10:00:00.000Z","u":{"_id":"u1","username":"john"}},{"_id":"m3","rid":"GENERAL","msg":"new deploy","ts":"2018-03-03T10:00:00.000Z","u":{"_id":"u1","username":"john"}}`,
+		"support": `{"_id":"m2","rid":"support","msg":"deploy failed","ts":"2018-03-02T10:00:00.000Z","u":{"_id":"u2","username":"jane"}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/rooms.get":
+			w.Write([]byte(`{"update":[{"_id":"GENERAL","t":"c","name":"general"},{"_id":"support","t":"p","name":"support"},{"_id":"dm","t":"d"},{"_id":"random","t":"c","name":"random"}],"remove":[],"success":true}`))
+		case "/api/v1/chat.search":
+			require.Equal(t, "deploy", r.URL.Query().Get("searchText"))
+			require.Equal(t, "5", r.URL.Query().Get("count"))
+
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			searched = append(searched, r.URL.Query().Get("roomId"))
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			fmt.Fprintf(w, `{"messages":[%s],"success":true}`, messages[r.URL.Query().Get("roomId")])
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	results, err := client.SearchAllRooms("deploy", &SearchOptions{
+		Count:       5,
+		Concurrency: 2,
+		RoomTypes:   []string{RoomTypeChannel, RoomTypeGroup},
+	})
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"GENERAL", "support", "random"}, searched)
+	require.True(t, peak <= 2)
+
+	require.Equal(t, 3, len(results))
+	require.Equal(t, "m3", results[0].Message.ID)
+	require.Equal(t, "general", results[0].Room.Name)
+	require.Equal(t, "m2", results[1].Message.ID)
+	require.Equal(t, "support", results[1].Room.Name)
+	require.Equal(t, "m1", results[2].Message.ID)
+}
+
+func TestSearchAllRoomsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/rooms.get" {
+			w.Write([]byte(`{"update":[{"_id":"GENERAL","t":"c"}],"success":true}`))
+			return
+		}
+		w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	_, err := client.SearchAllRooms("deploy", nil)
+	require.Error(t, err)
+}
+
+func TestSearchAllRoomsNotAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("roomId") {
+		case "":
+			w.Write([]byte(`{"update":[{"_id":"GENERAL","t":"c"},{"_id":"secret","t":"p"}],"success":true}`))
+		case "secret":
+			w.Write([]byte(`{"success":false,"error":"error-not-allowed"}`))
+		default:
+			w.Write([]byte(`{"messages":[{"_id":"m1","rid":"GENERAL","msg":"deploy"}],"success":true}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	results, err := client.SearchAllRooms("deploy", nil)
+	require.EqualError(t, err, "search room secret failed: error-not-allowed")
+	require.Nil(t, results)
+}
@@ -0,0 +1,237 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	SettingTypeBoolean = "boolean"
+	SettingTypeInt     = "int"
+	SettingTypeString  = "string"
+	SettingTypeSelect  = "select"
+	SettingTypeColor   = "color"
+	SettingTypeAction  = "action"
+)
+
+type Setting struct {
+	ID           string               `json:"_id"`
+	Value        interface{}          `json:"value"`
+	Type         string               `json:"type,omitempty"`
+	Group        string               `json:"group,omitempty"`
+	Section      string               `json:"section,omitempty"`
+	Public       bool                 `json:"public,omitempty"`
+	Enterprise   bool                 `json:"enterprise,omitempty"`
+	PackageValue interface{}          `json:"packageValue,omitempty"`
+	Editor       string               `json:"editor,omitempty"`
+	Values       []SettingSelectValue `json:"values,omitempty"`
+	I18nLabel    string               `json:"i18nLabel,omitempty"`
+	UpdatedAt    time.Time            `json:"_updatedAt,omitempty"`
+}
+
+type SettingSelectValue struct {
+	Key       string `json:"key"`
+	I18nLabel string `json:"i18nLabel"`
+}
+
+type SettingsResponse struct {
+	Settings []Setting `json:"settings"`
+	Count    int       `json:"count"`
+	Offset   int       `json:"offset"`
+	Total    int       `json:"total"`
+	Success  bool      `json:"success"`
+}
+
+type SettingsRequest struct {
+	// Group filters settings by group, e.g. "Accounts"
+	Group string
+	// IDs filters settings by id
+	IDs             []string
+	IncludeDefaults bool
+}
+
+type SettingResponse struct {
+	Setting
+	Success bool `json:"success"`
+}
+
+type UpdateSettingRequest struct {
+	Value interface{} `json:"value,omitempty"`
+	// Editor is the color editor, "color" or "expression", for color settings
+	Editor string `json:"editor,omitempty"`
+	// Execute runs the method of an action setting
+	Execute bool `json:"execute,omitempty"`
+}
+
+type SettingsOAuthResponse struct {
+	Services []OAuthService `json:"services"`
+	Success  bool           `json:"success"`
+}
+
+type OAuthService struct {
+	ID               string `json:"_id"`
+	Name             string `json:"name"`
+	Service          string `json:"service"`
+	ClientID         string `json:"clientId"`
+	Custom           bool   `json:"custom"`
+	ButtonLabelText  string `json:"buttonLabelText"`
+	ButtonColor      string `json:"buttonColor"`
+	ButtonLabelColor string `json:"buttonLabelColor"`
+}
+
+// BoolValue returns the value of a boolean setting.
+func (s *Setting) BoolValue() (bool, error) {
+	v, ok := s.Value.(bool)
+	if !ok {
+		return false, fmt.Errorf("setting %s is %T, not bool", s.ID, s.Value)
+	}
+
+	return v, nil
+}
+
+// IntValue returns the value of an int setting.
+func (s *Setting) IntValue() (int, error) {
+	v, ok := s.Value.(float64)
+	if !ok || v != float64(int(v)) {
+		return 0, fmt.Errorf("setting %s is %T, not int", s.ID, s.Value)
+	}
+
+	return int(v), nil
+}
+
+// StringValue returns the value of a string, select or color setting.
+func (s *Setting) StringValue() (string, error) {
+	v, ok := s.Value.(string)
+	if !ok {
+		return "", fmt.Errorf("setting %s is %T, not string", s.ID, s.Value)
+	}
+
+	return v, nil
+}
+
+// SettingsPublic lists the public settings.
+func (c *Client) SettingsPublic() (*SettingsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/settings.public", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SettingsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettingsList lists the private settings. Requires the view-privileged-setting permission.
+func (c *Client) SettingsList(param *SettingsRequest) (*SettingsResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/settings", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter := map[string]interface{}{}
+	if param.Group != "" {
+		filter["group"] = param.Group
+	}
+	if len(param.IDs) > 0 {
+		filter["_id"] = map[string]interface{}{"$in": param.IDs}
+	}
+
+	url := req.URL.Query()
+	if len(filter) > 0 {
+		q, _ := json.Marshal(filter)
+		url.Add("query", string(q))
+	}
+	if param.IncludeDefaults {
+		url.Add("includeDefaults", "true")
+	}
+	req.URL.RawQuery = url.Encode()
+
+	res := SettingsResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettingsGet gets a setting by id.
+func (c *Client) SettingsGet(id string) (*SettingResponse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/settings/%s", c.baseURL, c.apiVersion, url.PathEscape(id)),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SettingResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettingsUpdate updates the value of a setting, or runs an action setting.
+func (c *Client) SettingsUpdate(id string, param *UpdateSettingRequest) (*SimpleSuccessResponse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/settings/%s", c.baseURL, c.apiVersion, url.PathEscape(id)),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettingsOAuth lists the OAuth services configured on the server.
+func (c *Client) SettingsOAuth() (*SettingsOAuthResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/settings.oauth", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SettingsOAuthResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
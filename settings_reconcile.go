@@ -0,0 +1,164 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// settingsPageSize is the page size used to read the live settings.
+const settingsPageSize = 100
+
+// SettingChange is a setting whose live value differs from the desired one.
+type SettingChange struct {
+	ID      string
+	Current interface{}
+	Desired interface{}
+	// Missing is true if the setting does not exist on the server
+	Missing bool
+}
+
+// LoadDesiredSettings reads a YAML or JSON file mapping setting ids to values.
+func LoadDesiredSettings(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := map[string]interface{}{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(b, &desired)
+	default:
+		err = yaml.Unmarshal(b, &desired)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse desired settings %s: %w", path, err)
+	}
+
+	return desired, nil
+}
+
+// DiffSettings compares the desired settings with the live server and returns
+// the changes sorted by setting id.
+func (c *Client) DiffSettings(desired map[string]interface{}) ([]SettingChange, error) {
+	ids := make([]string, 0, len(desired))
+	for id := range desired {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	live := map[string]interface{}{}
+	for offset := 0; ; offset += settingsPageSize {
+		res, err := c.Count(settingsPageSize).Offset(offset).SettingsList(&SettingsRequest{IDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Success {
+			return nil, fmt.Errorf("list settings failed")
+		}
+
+		for _, s := range res.Settings {
+			live[s.ID] = s.Value
+		}
+
+		if len(res.Settings) == 0 || offset+len(res.Settings) >= res.Total {
+			break
+		}
+	}
+
+	changes := []SettingChange{}
+	for _, id := range ids {
+		want, err := normalizeSettingValue(desired[id])
+		if err != nil {
+			return nil, fmt.Errorf("setting %s: %w", id, err)
+		}
+
+		current, ok := live[id]
+		if !ok {
+			changes = append(changes, SettingChange{ID: id, Desired: want, Missing: true})
+			continue
+		}
+
+		if !reflect.DeepEqual(current, want) {
+			changes = append(changes, SettingChange{ID: id, Current: current, Desired: want})
+		}
+	}
+
+	return changes, nil
+}
+
+// ApplySettings updates the settings to their desired values. Missing settings
+// are skipped.
+func (c *Client) ApplySettings(changes []SettingChange) error {
+	for _, change := range changes {
+		if change.Missing {
+			continue
+		}
+
+		res, err := c.SettingsUpdate(change.ID, &UpdateSettingRequest{Value: change.Desired})
+		if err != nil {
+			return fmt.Errorf("update setting %s: %w", change.ID, err)
+		}
+		if !res.Success {
+			return fmt.Errorf("update setting %s: %s", change.ID, res.Error)
+		}
+	}
+
+	return nil
+}
+
+// WriteSettingsDiff writes a human-readable diff of the changes to w.
+func WriteSettingsDiff(w io.Writer, changes []SettingChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "settings are up to date")
+		return err
+	}
+
+	for _, change := range changes {
+		var err error
+		if change.Missing {
+			_, err = fmt.Fprintf(w, "! %s: not found on server (desired %s)\n", change.ID, formatSettingValue(change.Desired))
+		} else {
+			_, err = fmt.Fprintf(w, "~ %s: %s -> %s\n", change.ID, formatSettingValue(change.Current), formatSettingValue(change.Desired))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizeSettingValue converts a value decoded from YAML to the types
+// encoding/json uses, so it can be compared with the live value.
+func normalizeSettingValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func formatSettingValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
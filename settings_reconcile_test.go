@@ -0,0 +1,73 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDesiredSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	yamlPath := filepath.Join(dir, "settings.yaml")
+	require.NoError(t, ioutil.WriteFile(yamlPath, []byte("Accounts_TwoFactorAuthentication_Enforce_Password_Fallback: true\nAccounts_TwoFactorAuthentication_MaxDelta: 1\n"), 0600))
+	desired, err := LoadDesiredSettings(yamlPath)
+	require.NoError(t, err)
+	require.Equal(t, true, desired["Accounts_TwoFactorAuthentication_Enforce_Password_Fallback"])
+	require.Equal(t, 1, desired["Accounts_TwoFactorAuthentication_MaxDelta"])
+
+	jsonPath := filepath.Join(dir, "settings.json")
+	require.NoError(t, ioutil.WriteFile(jsonPath, []byte(`{"Site_Name":"Rocket.Chat"}`), 0600))
+	desired, err = LoadDesiredSettings(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, "Rocket.Chat", desired["Site_Name"])
+}
+
+func TestDiffAndApplySettings(t *testing.T) {
+	updated := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updated[filepath.Base(r.URL.Path)] = body["value"]
+			_, err := w.Write([]byte(`{"success":true}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"settings":[{"_id":"Accounts_TwoFactorAuthentication_Enforce_Password_Fallback","value":false},{"_id":"Accounts_TwoFactorAuthentication_MaxDelta","value":1}],"count":2,"offset":0,"total":2,"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	changes, err := client.DiffSettings(map[string]interface{}{
+		"Accounts_TwoFactorAuthentication_Enforce_Password_Fallback": true,
+		"Accounts_TwoFactorAuthentication_MaxDelta":                  1,
+		"Unknown_Setting": "value",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []SettingChange{
+		{ID: "Accounts_TwoFactorAuthentication_Enforce_Password_Fallback", Current: false, Desired: true},
+		{ID: "Unknown_Setting", Desired: "value", Missing: true},
+	}, changes)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, WriteSettingsDiff(&buf, changes))
+	require.Equal(t, "~ Accounts_TwoFactorAuthentication_Enforce_Password_Fallback: false -> true\n! Unknown_Setting: not found on server (desired \"value\")\n", buf.String())
+
+	require.NoError(t, client.ApplySettings(changes))
+	require.Equal(t, map[string]interface{}{
+		"Accounts_TwoFactorAuthentication_Enforce_Password_Fallback": true,
+	}, updated)
+}
@@ -0,0 +1,109 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsPublic(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"settings":[{"_id":"Site_Name","value":"Rocket.Chat"},{"_id":"Accounts_AllowRegistration","value":true}],"count":2,"offset":0,"total":2,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SettingsPublic()
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(resp.Settings))
+	name, err := resp.Settings[0].StringValue()
+	require.NoError(t, err)
+	require.Equal(t, "Rocket.Chat", name)
+	allow, err := resp.Settings[1].BoolValue()
+	require.NoError(t, err)
+	require.True(t, allow)
+	require.Equal(t, 2, resp.Total)
+	require.True(t, resp.Success)
+}
+
+func TestSettingsList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.JSONEq(t, `{"group":"Accounts","_id":{"$in":["Accounts_TwoFactorAuthentication_MaxDelta"]}}`, r.URL.Query().Get("query"))
+		_, err := w.Write([]byte(`{"settings":[{"_id":"Accounts_TwoFactorAuthentication_MaxDelta","value":1,"type":"int","group":"Accounts"}],"count":1,"offset":0,"total":1,"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SettingsList(&SettingsRequest{
+		Group: "Accounts",
+		IDs:   []string{"Accounts_TwoFactorAuthentication_MaxDelta"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(resp.Settings))
+	require.Equal(t, SettingTypeInt, resp.Settings[0].Type)
+	delta, err := resp.Settings[0].IntValue()
+	require.NoError(t, err)
+	require.Equal(t, 1, delta)
+	_, err = resp.Settings[0].BoolValue()
+	require.Error(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestSettingsGet(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"_id":"Livechat_enabled","value":false,"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SettingsGet("Livechat_enabled")
+	require.NoError(t, err)
+
+	require.Equal(t, "Livechat_enabled", resp.ID)
+	enabled, err := resp.BoolValue()
+	require.NoError(t, err)
+	require.False(t, enabled)
+	require.True(t, resp.Success)
+
+	_, err = client.SettingsGet("")
+	require.Error(t, err)
+}
+
+func TestSettingsUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/settings/Layout_Sidenav_Footer", r.URL.Path)
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, false, body["value"])
+		_, err := w.Write([]byte(`{"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SettingsUpdate("Layout_Sidenav_Footer", &UpdateSettingRequest{Value: false})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestSettingsOAuth(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"services":[{"_id":"github","name":"github","service":"github","clientId":"abc","custom":false,"buttonLabelText":"","buttonColor":"#13679A","buttonLabelColor":"#FFFFFF"}],"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SettingsOAuth()
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(resp.Services))
+	require.Equal(t, "github", resp.Services[0].Service)
+	require.Equal(t, "abc", resp.Services[0].ClientID)
+	require.True(t, resp.Success)
+}
@@ -0,0 +1,165 @@
+package gorocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Subscription is the membership of a user in a room, with its read state.
+type Subscription struct {
+	ID            string    `json:"_id"`
+	RoomID        string    `json:"rid"`
+	U             UChat     `json:"u"`
+	Name          string    `json:"name"`
+	FName         string    `json:"fname,omitempty"`
+	Type          string    `json:"t"`
+	Open          bool      `json:"open"`
+	Alert         bool      `json:"alert"`
+	Favorite      bool      `json:"f,omitempty"`
+	Unread        int       `json:"unread"`
+	UserMentions  int       `json:"userMentions"`
+	GroupMentions int       `json:"groupMentions"`
+	Roles         []string  `json:"roles,omitempty"`
+	Ts            time.Time `json:"ts"`
+	LastSeen      time.Time `json:"ls"`
+	UpdatedAt     time.Time `json:"_updatedAt"`
+}
+
+type SubscriptionsGetResponse struct {
+	Update  []Subscription `json:"update"`
+	Remove  []Subscription `json:"remove"`
+	Success bool           `json:"success"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type SubscriptionResponse struct {
+	Subscription *Subscription `json:"subscription"`
+	Success      bool          `json:"success"`
+	Error        string        `json:"error,omitempty"`
+}
+
+type SubscriptionsReadRequest struct {
+	RoomID string `json:"rid"`
+	// ReadThreads marks the threads of the room as read too
+	ReadThreads bool `json:"readThreads,omitempty"`
+}
+
+// SubscriptionsUnreadRequest marks a room as unread, either entirely or from
+// the given message on.
+type SubscriptionsUnreadRequest struct {
+	RoomID             string
+	FirstUnreadMessage string
+}
+
+// SubscriptionsGet lists the subscriptions of the user. If updatedSince is
+// set, only the subscriptions changed and removed since then are returned.
+func (c *Client) SubscriptionsGet(updatedSince time.Time) (*SubscriptionsGetResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/subscriptions.get", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !updatedSince.IsZero() {
+		url := req.URL.Query()
+		url.Add("updatedSince", updatedSince.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = url.Encode()
+	}
+
+	res := SubscriptionsGetResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SubscriptionsGetOne gets the subscription of the user to a room.
+// Subscription is nil if the user is not a member of the room.
+func (c *Client) SubscriptionsGetOne(roomID string) (*SubscriptionResponse, error) {
+	if roomID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/subscriptions.getOne", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Query()
+	url.Add("roomId", roomID)
+	req.URL.RawQuery = url.Encode()
+
+	res := SubscriptionResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SubscriptionsRead marks a room as read.
+func (c *Client) SubscriptionsRead(param *SubscriptionsReadRequest) (*SimpleSuccessResponse, error) {
+	if param.RoomID == "" {
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/subscriptions.read", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SubscriptionsUnread marks a room as unread.
+func (c *Client) SubscriptionsUnread(param *SubscriptionsUnreadRequest) (*SimpleSuccessResponse, error) {
+	var body interface{}
+	switch {
+	case param.FirstUnreadMessage != "":
+		body = map[string]interface{}{"firstUnreadMessage": map[string]string{"_id": param.FirstUnreadMessage}}
+	case param.RoomID != "":
+		body = map[string]string{"roomId": param.RoomID}
+	default:
+		return nil, fmt.Errorf("false parameters")
+	}
+
+	opt, _ := json.Marshal(body)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/subscriptions.unread", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
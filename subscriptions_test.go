@@ -0,0 +1,90 @@
+package gorocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionsGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/subscriptions.get", r.URL.Path)
+		require.Equal(t, "2018-03-01T18:00:00Z", r.URL.Query().Get("updatedSince"))
+
+		w.Write([]byte(`{"update":[{"_id":"s1","rid":"GENERAL","u":{"_id":"u1","username":"john"},"name":"general","t":"c","open":true,"alert":true,"unread":3,"userMentions":1,"groupMentions":0,"ts":"2018-01-21T21:04:34.591Z","ls":"2018-03-01T18:02:26.828Z","_updatedAt":"2018-03-01T18:02:26.828Z"}],"remove":[{"_id":"s2","rid":"old"}],"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SubscriptionsGet(time.Date(2018, 3, 1, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, resp.Update, 1)
+	require.Equal(t, "GENERAL", resp.Update[0].RoomID)
+	require.Equal(t, 3, resp.Update[0].Unread)
+	require.Equal(t, 1, resp.Update[0].UserMentions)
+	require.Equal(t, "old", resp.Remove[0].RoomID)
+}
+
+func TestSubscriptionsGetOne(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/subscriptions.getOne", r.URL.Path)
+		require.Equal(t, "GENERAL", r.URL.Query().Get("roomId"))
+
+		w.Write([]byte(`{"subscription":{"_id":"s1","rid":"GENERAL","name":"general","t":"c","unread":2},"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SubscriptionsGetOne("GENERAL")
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Subscription.Unread)
+}
+
+func TestSubscriptionsRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/subscriptions.read", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"rid": "GENERAL", "readThreads": true}, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.SubscriptionsRead(&SubscriptionsReadRequest{RoomID: "GENERAL", ReadThreads: true})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestSubscriptionsUnread(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/subscriptions.unread", r.URL.Path)
+
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	_, err := client.SubscriptionsUnread(&SubscriptionsUnreadRequest{RoomID: "GENERAL"})
+	require.NoError(t, err)
+	_, err = client.SubscriptionsUnread(&SubscriptionsUnreadRequest{FirstUnreadMessage: "m1"})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]interface{}{
+		{"roomId": "GENERAL"},
+		{"firstUnreadMessage": map[string]interface{}{"_id": "m1"}},
+	}, bodies)
+
+	_, err = client.SubscriptionsUnread(&SubscriptionsUnreadRequest{})
+	require.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package gorocket
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UserActivityTyping is the user-activity of a user typing a message.
+const UserActivityTyping = "user-typing"
+
+// typingInterval is how often WithTyping repeats the typing notification,
+// which the clients hide after a few seconds.
+var typingInterval = 5 * time.Second
+
+// SetTyping shows or hides the user as typing in a room.
+func (r *RealtimeClient) SetTyping(ctx context.Context, roomID string, typing bool) error {
+	if roomID == "" {
+		return fmt.Errorf("false parameters")
+	}
+
+	username, err := r.currentUsername()
+	if err != nil {
+		return err
+	}
+
+	activities := []string{}
+	if typing {
+		activities = append(activities, UserActivityTyping)
+	}
+
+	return r.call(ctx, "stream-notify-room", nil,
+		roomID+"/user-activity", username, activities, map[string]interface{}{})
+}
+
+// WithTyping shows the user as typing in a room while fn runs. fn runs even
+// if the indicator cannot be shown, and its error takes precedence over the
+// errors of the indicator. The ctx passed to fn is canceled when fn returns.
+func (r *RealtimeClient) WithTyping(ctx context.Context, roomID string, fn func(ctx context.Context) error) error {
+	startErr := r.SetTyping(ctx, roomID, true)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(typingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.SetTyping(ctx, roomID, true)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := fn(ctx)
+	cancel()
+	<-stopped
+
+	if err == nil {
+		err = startErr
+	}
+
+	// ctx may be done already, the indicator is hidden anyway
+	stopCtx, stop := context.WithTimeout(context.Background(), 10*time.Second)
+	defer stop()
+
+	if stopErr := r.SetTyping(stopCtx, roomID, false); err == nil {
+		err = stopErr
+	}
+
+	return err
+}
+
+// currentUsername returns the username of the logged in user.
+func (r *RealtimeClient) currentUsername() (string, error) {
+	r.mu.Lock()
+	username := r.username
+	r.mu.Unlock()
+
+	if username != "" {
+		return username, nil
+	}
+
+	me, err := r.client.Me()
+	if err != nil {
+		return "", err
+	}
+	if me.Username == "" {
+		return "", fmt.Errorf("unknown username of the logged in user")
+	}
+
+	r.mu.Lock()
+	r.username = me.Username
+	r.mu.Unlock()
+
+	return me.Username, nil
+}
@@ -0,0 +1,100 @@
+package gorocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTypingServer(t *testing.T, mu *sync.Mutex, activities *[][]interface{}) (*Client, *RealtimeClient, func()) {
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/me", r.URL.Path)
+		w.Write([]byte(`{"_id":"u1","username":"bot","success":true}`))
+	})
+
+	server := newDDPServer(t, rest, func(c *ddpConn, msg map[string]interface{}) {
+		require.Equal(t, "stream-notify-room", msg["method"])
+		params := msg["params"].([]interface{})
+		require.Equal(t, "GENERAL/user-activity", params[0])
+		require.Equal(t, "bot", params[1])
+
+		mu.Lock()
+		*activities = append(*activities, params[2].([]interface{}))
+		mu.Unlock()
+
+		c.result(msg["id"].(string), nil)
+	})
+
+	client, rt := newTestRealtimeClient(t, server)
+	return client, rt, func() {
+		rt.Close()
+		server.Close()
+	}
+}
+
+func TestSetTyping(t *testing.T) {
+	var mu sync.Mutex
+	var activities [][]interface{}
+	_, rt, closeAll := newTypingServer(t, &mu, &activities)
+	defer closeAll()
+
+	require.NoError(t, rt.SetTyping(context.Background(), "GENERAL", true))
+	require.NoError(t, rt.SetTyping(context.Background(), "GENERAL", false))
+	require.Equal(t, [][]interface{}{{UserActivityTyping}, {}}, activities)
+}
+
+func TestWithTyping(t *testing.T) {
+	defer func(d time.Duration) { typingInterval = d }(typingInterval)
+	typingInterval = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	var activities [][]interface{}
+	_, rt, closeAll := newTypingServer(t, &mu, &activities)
+	defer closeAll()
+
+	failed := errors.New("failed")
+	err := rt.WithTyping(context.Background(), "GENERAL", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return failed
+	})
+	require.Equal(t, failed, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.True(t, len(activities) > 2)
+	require.Equal(t, []interface{}{UserActivityTyping}, activities[0])
+	require.Equal(t, []interface{}{}, activities[len(activities)-1])
+}
+
+func TestWithTypingIndicatorFailed(t *testing.T) {
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"success":false}`))
+	})
+	server := newDDPServer(t, rest, func(c *ddpConn, msg map[string]interface{}) {
+		t.Errorf("unexpected message %v", msg)
+	})
+	defer server.Close()
+
+	_, rt := newTestRealtimeClient(t, server)
+	defer rt.Close()
+
+	ran := false
+	err := rt.WithTyping(context.Background(), "GENERAL", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	require.True(t, ran)
+	require.EqualError(t, err, "unknown username of the logged in user")
+
+	failed := errors.New("failed")
+	err = rt.WithTyping(context.Background(), "GENERAL", func(ctx context.Context) error {
+		return failed
+	})
+	require.Equal(t, failed, err)
+}
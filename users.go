@@ -25,17 +25,17 @@ type user struct {
 }
 
 type NewUser struct {
-	Email                 string   `json:"email"`
-	Name                  string   `json:"name"`
-	Password              string   `json:"password"`
-	Username              string   `json:"username"`
-	Active                bool     `json:"active,omitempty"`
-	Roles                 []string `json:"roles,omitempty"`
-	JoinDefaultChannels   bool     `json:"joinDefaultChannels,omitempty"`
-	RequirePasswordChange bool     `json:"requirePasswordChange,omitempty"`
-	SendWelcomeEmail      bool     `json:"sendWelcomeEmail,omitempty"`
-	Verified              bool     `json:"verified,omitempty"`
-	CustomFields          string   `json:"customFields,omitempty"`
+	Email                 string       `json:"email"`
+	Name                  string       `json:"name"`
+	Password              string       `json:"password"`
+	Username              string       `json:"username"`
+	Active                bool         `json:"active,omitempty"`
+	Roles                 []string     `json:"roles,omitempty"`
+	JoinDefaultChannels   bool         `json:"joinDefaultChannels,omitempty"`
+	RequirePasswordChange bool         `json:"requirePasswordChange,omitempty"`
+	SendWelcomeEmail      bool         `json:"sendWelcomeEmail,omitempty"`
+	Verified              bool         `json:"verified,omitempty"`
+	CustomFields          CustomFields `json:"customFields,omitempty"`
 }
 
 type UserCreateResponse struct {
@@ -44,18 +44,19 @@ type UserCreateResponse struct {
 }
 
 type userCreateInfo struct {
-	ID        string       `json:"_id"`
-	CreatedAt time.Time    `json:"createdAt"`
-	Services  userServices `json:"services"`
-	Username  string       `json:"username"`
-	Emails    []Email      `json:"emails"`
-	Type      string       `json:"type"`
-	Status    string       `json:"status"`
-	Active    bool         `json:"active"`
-	Roles     []string     `json:"roles"`
-	UpdatedAt time.Time    `json:"_updatedAt"`
-	Name      string       `json:"name"`
-	Settings  struct {
+	ID           string       `json:"_id"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	Services     userServices `json:"services"`
+	Username     string       `json:"username"`
+	Emails       []Email      `json:"emails"`
+	Type         string       `json:"type"`
+	Status       string       `json:"status"`
+	Active       bool         `json:"active"`
+	Roles        []string     `json:"roles"`
+	UpdatedAt    time.Time    `json:"_updatedAt"`
+	Name         string       `json:"name"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
+	Settings     struct {
 	} `json:"settings"`
 }
 
@@ -70,7 +71,9 @@ type UsersDelete struct {
 }
 
 type SimpleSuccessResponse struct {
-	Success bool `json:"success"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	ErrorType string `json:"errorType,omitempty"`
 }
 
 type SimpleUserRequest struct {
@@ -106,6 +109,22 @@ type NewTokenResponse struct {
 	Success bool   `json:"success"`
 }
 
+type PersonalAccessTokensResponse struct {
+	Tokens  []PersonalAccessToken `json:"tokens"`
+	Success bool                  `json:"success"`
+}
+
+type PersonalAccessToken struct {
+	Name            string    `json:"name"`
+	CreatedAt       time.Time `json:"createdAt"`
+	LastTokenPart   string    `json:"lastTokenPart"`
+	BypassTwoFactor bool      `json:"bypassTwoFactor"`
+}
+
+type PersonalAccessTokenName struct {
+	TokenName string `json:"tokenName"`
+}
+
 type GetStatusResponse struct {
 	Message          string `json:"message"`
 	ConnectionStatus string `json:"connectionStatus"`
@@ -119,15 +138,16 @@ type UsersInfoResponse struct {
 }
 
 type SingleUserInfo struct {
-	ID         string    `json:"_id"`
-	CreatedAt  time.Time `json:"createdAt"`
-	Type       string    `json:"type"`
-	Status     string    `json:"status"`
-	Active     bool      `json:"active"`
-	Name       string    `json:"name"`
-	UtcOffset  float64   `json:"utcOffset"`
-	Username   string    `json:"username"`
-	AvatarETag string    `json:"avatarETag,omitempty"`
+	ID           string       `json:"_id"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	Type         string       `json:"type"`
+	Status       string       `json:"status"`
+	Active       bool         `json:"active"`
+	Name         string       `json:"name"`
+	UtcOffset    float64      `json:"utcOffset"`
+	Username     string       `json:"username"`
+	AvatarETag   string       `json:"avatarETag,omitempty"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
 }
 
 type UserRegisterRequest struct {
@@ -149,15 +169,16 @@ type UserUpdateRequest struct {
 }
 
 type UserUpdateData struct {
-	Email                 string   `json:"email,omitempty"`
-	Name                  string   `json:"name,omitempty"`
-	Password              string   `json:"password,omitempty"`
-	Username              string   `json:"username,omitempty"`
-	Active                bool     `json:"active,omitempty"`
-	Roles                 []string `json:"roles,omitempty"`
-	RequirePasswordChange bool     `json:"requirePasswordChange,omitempty"`
-	SendWelcomeEmail      bool     `json:"sendWelcomeEmail,omitempty"`
-	Verified              bool     `json:"verified,omitempty"`
+	Email                 string       `json:"email,omitempty"`
+	Name                  string       `json:"name,omitempty"`
+	Password              string       `json:"password,omitempty"`
+	Username              string       `json:"username,omitempty"`
+	Active                bool         `json:"active,omitempty"`
+	Roles                 []string     `json:"roles,omitempty"`
+	RequirePasswordChange bool         `json:"requirePasswordChange,omitempty"`
+	SendWelcomeEmail      bool         `json:"sendWelcomeEmail,omitempty"`
+	Verified              bool         `json:"verified,omitempty"`
+	CustomFields          CustomFields `json:"customFields,omitempty"`
 }
 
 type UserUpdateResponse struct {
@@ -173,14 +194,15 @@ type userUpdateInfo struct {
 			Bcrypt string `json:"bcrypt"`
 		} `json:"password"`
 	} `json:"services"`
-	Username  string    `json:"username"`
-	Emails    []Email   `json:"emails"`
-	Type      string    `json:"type"`
-	Status    string    `json:"status"`
-	Active    bool      `json:"active"`
-	Roles     []string  `json:"roles"`
-	UpdatedAt time.Time `json:"_updatedAt"`
-	Name      string    `json:"name"`
+	Username     string       `json:"username"`
+	Emails       []Email      `json:"emails"`
+	Type         string       `json:"type"`
+	Status       string       `json:"status"`
+	Active       bool         `json:"active"`
+	Roles        []string     `json:"roles"`
+	UpdatedAt    time.Time    `json:"_updatedAt"`
+	Name         string       `json:"name"`
+	CustomFields CustomFields `json:"customFields,omitempty"`
 }
 
 // UsersPresence gets all connected users presence
@@ -284,7 +306,15 @@ func (c *Client) UsersDeactivateIdle(params *DeactivateRequest) (*DeactivateResp
 }
 
 // UsersDeleteOwnAccount deletes your own account.
+// If the server asks for a two-factor code, use UsersDeleteOwnAccountWithCode.
 func (c *Client) UsersDeleteOwnAccount(pass string) (*SimpleSuccessResponse, error) {
+	return c.UsersDeleteOwnAccountWithCode(pass, "", "")
+}
+
+// UsersDeleteOwnAccountWithCode deletes your own account, sending the
+// two-factor code with the request. method is one of the TwoFactorMethod
+// constants.
+func (c *Client) UsersDeleteOwnAccountWithCode(pass, code, method string) (*SimpleSuccessResponse, error) {
 
 	param := struct {
 		Password string `json:"password"`
@@ -302,6 +332,30 @@ func (c *Client) UsersDeleteOwnAccount(pass string) (*SimpleSuccessResponse, err
 		return nil, err
 	}
 
+	if code != "" {
+		req.Header.Set("X-2fa-code", code)
+		req.Header.Set("X-2fa-method", method)
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UsersEnableEmail2FA enables two-factor authentication by email
+func (c *Client) UsersEnableEmail2FA() (*SimpleSuccessResponse, error) {
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/users.2fa.enableEmail", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
 	res := SimpleSuccessResponse{}
 
 	if err := c.sendRequest(req, &res); err != nil {
@@ -311,6 +365,59 @@ func (c *Client) UsersDeleteOwnAccount(pass string) (*SimpleSuccessResponse, err
 	return &res, nil
 }
 
+// UsersDisableEmail2FA disables two-factor authentication by email. The
+// server asks for a two-factor code, sent with the request. method is one of
+// the TwoFactorMethod constants.
+func (c *Client) UsersDisableEmail2FA(code, method string) (*SimpleSuccessResponse, error) {
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/users.2fa.disableEmail", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if code != "" {
+		req.Header.Set("X-2fa-code", code)
+		req.Header.Set("X-2fa-method", method)
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UsersSendEmailCode sends a two-factor code to the email of the user
+func (c *Client) UsersSendEmailCode(emailOrUsername string) (*SimpleSuccessResponse, error) {
+	param := struct {
+		EmailOrUsername string `json:"emailOrUsername"`
+	}{
+		EmailOrUsername: emailOrUsername,
+	}
+
+	opt, _ := json.Marshal(param)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/users.2fa.sendEmailCode", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendAnonymous(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 // UsersForgotPassword send an email to reset your password
 func (c *Client) UsersForgotPassword(email string) (*SimpleSuccessResponse, error) {
 	param := struct {
@@ -359,6 +466,90 @@ func (c *Client) UsersGeneratePersonalAccessToken(params *GetNewToken) (*NewToke
 	return &res, nil
 }
 
+// UsersGetPersonalAccessTokens gets the personal access tokens of the authenticated user
+func (c *Client) UsersGetPersonalAccessTokens() (*PersonalAccessTokensResponse, error) {
+	req, err := http.NewRequest("GET",
+		fmt.Sprintf("%s/%s/users.getPersonalAccessTokens", c.baseURL, c.apiVersion),
+		nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := PersonalAccessTokensResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UsersRegeneratePersonalAccessToken regenerates a personal access token
+func (c *Client) UsersRegeneratePersonalAccessToken(params *PersonalAccessTokenName) (*NewTokenResponse, error) {
+	opt, _ := json.Marshal(params)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/users.regeneratePersonalAccessToken", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewTokenResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UsersRemovePersonalAccessToken removes a personal access token
+func (c *Client) UsersRemovePersonalAccessToken(params *PersonalAccessTokenName) (*SimpleSuccessResponse, error) {
+	opt, _ := json.Marshal(params)
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/users.removePersonalAccessToken", c.baseURL, c.apiVersion),
+		bytes.NewBuffer(opt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := SimpleSuccessResponse{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RotatePersonalAccessToken regenerates the named personal access token and
+// switches the client to the new token. The client keeps using the old token
+// if the server does not return a new one. It fails without regenerating the
+// token if the credential provider of the client cannot take a new token.
+func (c *Client) RotatePersonalAccessToken(tokenName string) (*NewTokenResponse, error) {
+	if !c.canSetAuthToken() {
+		return nil, fmt.Errorf("token %q not rotated: the credential provider cannot take a new token", tokenName)
+	}
+
+	res, err := c.UsersRegeneratePersonalAccessToken(&PersonalAccessTokenName{TokenName: tokenName})
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success || res.Token == "" {
+		return res, fmt.Errorf("token %q was not regenerated", tokenName)
+	}
+
+	c.setAuthToken(res.Token)
+
+	return res, nil
+}
+
 // UsersGetStatus gets the status of a user
 func (c *Client) UsersGetStatus(user *SimpleUserRequest) (*GetStatusResponse, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/users.getStatus", c.baseURL, c.apiVersion), nil)
@@ -1,6 +1,7 @@
 package gorocket
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -132,6 +133,80 @@ func TestUsersDeleteOwnAccount(t *testing.T) {
 	require.True(t, resp.Success)
 }
 
+func TestUsersDeleteOwnAccountTwoFactor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "123456", r.Header.Get("X-2fa-code"))
+		require.Equal(t, TwoFactorMethodTOTP, r.Header.Get("X-2fa-method"))
+		_, err := w.Write([]byte(`{"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersDeleteOwnAccountWithCode("password", "123456", TwoFactorMethodTOTP)
+
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestUsersEnableEmail2FA(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersEnableEmail2FA()
+
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestUsersDisableEmail2FA(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		Code:         http.StatusBadRequest,
+		ResponseBody: `{"success":false,"error":"TOTP Required [totp-required]","errorType":"totp-required"}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersDisableEmail2FA("", "")
+
+	require.NoError(t, err)
+	require.False(t, resp.Success)
+	require.Equal(t, "totp-required", resp.ErrorType)
+}
+
+func TestUsersDisableEmail2FAWithCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/users.2fa.disableEmail", r.URL.Path)
+		require.Equal(t, "654321", r.Header.Get("X-2fa-code"))
+		require.Equal(t, TwoFactorMethodEmail, r.Header.Get("X-2fa-method"))
+		_, err := w.Write([]byte(`{"success":true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersDisableEmail2FA("654321", TwoFactorMethodEmail)
+
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestUsersSendEmailCode(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersSendEmailCode("rocket.cat")
+
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
 func TestUsersForgotPassword(t *testing.T) {
 	server := httptest.NewServer(getHandler(t, &HandlerHelper{
 		ResponseBody: `{"success":true}`,
@@ -163,6 +238,97 @@ func TestUsersGeneratePersonalAccessToken(t *testing.T) {
 	require.True(t, resp.Success)
 }
 
+func TestUsersGetPersonalAccessTokens(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"tokens":[{"name":"deploy-bot","createdAt":"2023-10-05T18:55:02.996Z","lastTokenPart":"mSuj9Sk","bypassTwoFactor":true}],"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersGetPersonalAccessTokens()
+
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Tokens))
+	require.Equal(t, "deploy-bot", resp.Tokens[0].Name)
+	require.Equal(t, "2023-10-05T18:55:02.996Z", resp.Tokens[0].CreatedAt.Format("2006-01-02T15:04:05.999Z"))
+	require.Equal(t, "mSuj9Sk", resp.Tokens[0].LastTokenPart)
+	require.True(t, resp.Tokens[0].BypassTwoFactor)
+	require.True(t, resp.Success)
+}
+
+func TestUsersRegeneratePersonalAccessToken(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"token":"9sjDjAhSJmskAKW301mSuj9Sk2jdk99wuSjXPO201XlAk","success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersRegeneratePersonalAccessToken(&PersonalAccessTokenName{TokenName: "deploy-bot"})
+
+	require.NoError(t, err)
+	require.Equal(t, "9sjDjAhSJmskAKW301mSuj9Sk2jdk99wuSjXPO201XlAk", resp.Token)
+	require.True(t, resp.Success)
+}
+
+func TestUsersRemovePersonalAccessToken(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	resp, err := client.UsersRemovePersonalAccessToken(&PersonalAccessTokenName{TokenName: "deploy-bot"})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
+
+func TestRotatePersonalAccessToken(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"token":"new-token","success":true}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	client.setCredentials("user", "old-token")
+
+	resp, err := client.RotatePersonalAccessToken("deploy-bot")
+	require.NoError(t, err)
+	require.Equal(t, "new-token", resp.Token)
+
+	userID, xToken := client.credentials()
+	require.Equal(t, "user", userID)
+	require.Equal(t, "new-token", xToken)
+}
+
+func TestRotatePersonalAccessTokenFailed(t *testing.T) {
+	server := httptest.NewServer(getHandler(t, &HandlerHelper{
+		ResponseBody: `{"success":false}`,
+	}))
+	defer server.Close()
+
+	client := NewTestClientWithCustomHandler(t, server)
+	client.setCredentials("user", "old-token")
+
+	_, err := client.RotatePersonalAccessToken("deploy-bot")
+	require.Error(t, err)
+
+	_, xToken := client.credentials()
+	require.Equal(t, "old-token", xToken)
+}
+
+func TestRotatePersonalAccessTokenWithEnvCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewWithOptions(server.URL, WithCredentialProvider(NewEnvCredentials()))
+
+	_, err := client.RotatePersonalAccessToken("deploy-bot")
+	require.EqualError(t, err, `token "deploy-bot" not rotated: the credential provider cannot take a new token`)
+}
+
 func TestUsersGetStatus(t *testing.T) {
 	server := httptest.NewServer(getHandler(t, &HandlerHelper{
 		ResponseBody: `{"message":"Latest status","connectionStatus":"online","status":"online","success":true}`,
@@ -184,7 +350,7 @@ func TestUsersGetStatus(t *testing.T) {
 
 func TestUsersInfo(t *testing.T) {
 	server := httptest.NewServer(getHandler(t, &HandlerHelper{
-		ResponseBody: `{"user":{"_id":"5fRTXMt7DMJbpPJfh","createdAt":"2023-07-10T16:44:58.548Z","services":{"password":true,"email2fa":{"enabled":true,"changedAt":"2023-07-10T16:44:58.546Z"},"resume":{"loginTokens":[{"when":"2023-10-05T18:55:02.996Z","hashedToken":"..."},{"when":"2023-10-05T19:09:30.415Z","hashedToken":"....."},{"when":"2023-10-10T23:40:46.098Z","hashedToken":"...."}]}},"username":"test.john","emails":[{"address":"test.john@test.com","verified":true}],"type":"user","status":"offline","active":true,"roles":["user","admin"],"name":"Test John","requirePasswordChange":false,"lastLogin":"2023-10-10T23:40:46.093Z","statusConnection":"offline","utcOffset":1,"statusText":"","avatarETag":"GFoEi6wv3uAxnzDcD","nickname":"tesuser2","canViewAllInfo":true},"success":true}`,
+		ResponseBody: `{"user":{"_id":"5fRTXMt7DMJbpPJfh","createdAt":"2023-07-10T16:44:58.548Z","services":{"password":true,"email2fa":{"enabled":true,"changedAt":"2023-07-10T16:44:58.546Z"},"resume":{"loginTokens":[{"when":"2023-10-05T18:55:02.996Z","hashedToken":"..."},{"when":"2023-10-05T19:09:30.415Z","hashedToken":"....."},{"when":"2023-10-10T23:40:46.098Z","hashedToken":"...."}]}},"username":"test.john","emails":[{"address":"test.john@test.com","verified":true}],"type":"user","status":"offline","active":true,"roles":["user","admin"],"name":"Test John","requirePasswordChange":false,"lastLogin":"2023-10-10T23:40:46.093Z","statusConnection":"offline","utcOffset":1,"statusText":"","avatarETag":"GFoEi6wv3uAxnzDcD","nickname":"tesuser2","canViewAllInfo":true,"customFields":{"employeeId":"E-1024","costCentre":"R&D"}},"success":true}`,
 	}))
 	defer server.Close()
 
@@ -205,6 +371,8 @@ func TestUsersInfo(t *testing.T) {
 	require.Equal(t, "Test John", resp.User.Name)
 	require.Equal(t, 1.0, resp.User.UtcOffset)
 	require.Equal(t, "GFoEi6wv3uAxnzDcD", resp.User.AvatarETag)
+	require.Equal(t, "E-1024", resp.User.CustomFields["employeeId"])
+	require.Equal(t, "R&D", resp.User.CustomFields["costCentre"])
 	require.True(t, resp.Success)
 }
 
@@ -275,5 +443,6 @@ func TestUsersUpdate(t *testing.T) {
 	require.Equal(t, false, resp.User.Emails[0].Verified)
 	require.Equal(t, "user", resp.User.Roles[0])
 	require.Equal(t, "new name", resp.User.Name)
+	require.Equal(t, "userstwitter", resp.User.CustomFields["twitter"])
 	require.True(t, resp.Success)
 }